@@ -3,24 +3,54 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
+
+	"github.com/rfpludwick/resume/pkg/resume"
 )
 
 var (
 	flagBaseResumeFile   string
 	flagSecretResumeFile string
+	flagResumeFiles      resumeFilesFlag
+	flagKeyFile          string
+	flagProvenance       bool
 	flagControlsFile     string
 	flagGeneratedPdf     string
+	flagFormat           string
+	flagRenderer         string
+	flagFull             bool
+	flagPublic           bool
 	flagShowHelp         bool
 )
 
 func initFlags() {
 	flag.StringVar(&flagBaseResumeFile, "base-resume", "conf/resume/base.yaml", "Path to base resume file to use")
-	flag.StringVar(&flagSecretResumeFile, "secret-resume", "conf/resume/secret.yaml", "Path to secret resume file to use")
-	flag.StringVar(&flagControlsFile, "controls", "conf/controls/default.yaml", "Path to the controls file to use")
-	flag.StringVar(&flagGeneratedPdf, "output-pdf", "", "The filename to use for the generated PDF")
+	flag.StringVar(&flagSecretResumeFile, "secret-resume", "conf/resume/secret.yaml", "Path to secret resume file to use, if it exists; may be encrypted")
+	flag.Var(&flagResumeFiles, "resume", "Path to a resume file to merge, in order; may be repeated, and replaces base-resume and secret-resume")
+	flag.StringVar(&flagKeyFile, "key-file", "", "Path to a file holding the passphrase for encrypted resume files; otherwise it's read from "+PassphraseEnvironmentVariable)
+	flag.BoolVar(&flagProvenance, "provenance", false, "List the resume file each value of the merged resume came from, instead of generating")
+	flag.StringVar(&flagControlsFile, "controls", "conf/controls/default.yaml", "Path to the controls file to use; may also be a glob or a directory to build several at once")
+	flag.StringVar(&flagGeneratedPdf, "output-pdf", "", "The filename to use for the generated document, whatever its format")
+	flag.StringVar(&flagFormat, "format", resume.DefaultFormat, "The output format to generate; one of: "+strings.Join(resume.Formats(), ", "))
+	flag.StringVar(&flagRenderer, "renderer", "", "The renderer to use for the format, overriding the controls")
+	flag.BoolVar(&flagFull, "full", false, "Include every entry of the resume instead of only what the controls select")
+	flag.BoolVar(&flagPublic, "public", false, "Leave out the secret resume file, any encrypted resume files, and the email address and phone number, for a public document")
 	flag.BoolVar(&flagShowHelp, "help", false, "Show help")
 }
 
+// resumeFilesFlag collects every use of a repeatable flag
+type resumeFilesFlag []string
+
+func (f *resumeFilesFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *resumeFilesFlag) Set(value string) error {
+	*f = append(*f, value)
+
+	return nil
+}
+
 func parseFlags() {
 	flag.Parse()
 
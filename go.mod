@@ -6,4 +6,4 @@ require github.com/jung-kurt/gofpdf/v2 v2.17.3
 
 require gopkg.in/yaml.v3 v3.0.1
 
-require github.com/jung-kurt/gofpdf v1.16.2 // indirect
+require github.com/jung-kurt/gofpdf v1.16.2
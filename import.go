@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/rfpludwick/resume/pkg/resume"
+)
+
+// runImport converts a JSON Resume file into base and secret resume files
+func runImport(arguments []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+
+	var (
+		jsonResumeFile   string
+		baseResumeFile   string
+		secretResumeFile string
+		overwrite        bool
+	)
+
+	flags.StringVar(&jsonResumeFile, "json", "resume.json", "Path to the JSON Resume file to import")
+	flags.StringVar(&baseResumeFile, "base-resume", "conf/resume/base.yaml", "Path to the base resume file to write")
+	flags.StringVar(&secretResumeFile, "secret-resume", "conf/resume/secret.yaml", "Path to the secret resume file to write")
+	flags.BoolVar(&overwrite, "overwrite", false, "Overwrite the resume files if they already exist")
+
+	// ExitOnError takes care of any errors
+	_ = flags.Parse(arguments)
+
+	f, err := os.Open(jsonResumeFile)
+
+	if err != nil {
+		log.Fatal("Error opening JSON Resume file:", err)
+	}
+
+	base, secret, err := resume.ImportJsonResume(f)
+	f.Close()
+
+	if err != nil {
+		log.Fatal("Error importing JSON Resume file:", err)
+	}
+
+	// Check both files before writing either, so an import never stops halfway
+	if !overwrite {
+		for _, resumeFile := range []string{baseResumeFile, secretResumeFile} {
+			if _, err = os.Stat(resumeFile); err == nil {
+				log.Fatalf("Error writing resume file %s: it already exists; use --overwrite to replace it", resumeFile)
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				log.Fatal("Error checking resume file:", err)
+			}
+		}
+	}
+
+	if err = writeResumeFile(baseResumeFile, base, overwrite, 0644); err != nil {
+		log.Fatal("Error writing base resume file:", err)
+	}
+
+	// The secret file is only for its owner to read, like a decrypted one
+	if err = writeResumeFile(secretResumeFile, secret, overwrite, 0600); err != nil {
+		log.Fatal("Error writing secret resume file:", err)
+	}
+}
+
+func writeResumeFile(filename string, c *resume.Configuration, overwrite bool, perm os.FileMode) error {
+	mode := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+
+	if !overwrite {
+		mode |= os.O_EXCL
+	}
+
+	f, err := os.OpenFile(filename, mode, perm)
+
+	if err != nil {
+		return err
+	}
+
+	if err = resume.EncodeResume(f, c); err != nil {
+		f.Close()
+
+		return fmt.Errorf("error writing %s: %w", filename, err)
+	}
+
+	return f.Close()
+}
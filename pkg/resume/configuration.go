@@ -0,0 +1,722 @@
+package resume
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	CollapseMultiplePositionsCollapse   = "collapse"
+	CollapseMultiplePositionsTitlesOnly = "titles-only"
+	CollapseMultiplePositionsFull       = "full"
+)
+
+var (
+	PageBreakAlways = "always"
+	PageBreakAuto   = "auto"
+	PageBreakNever  = "never"
+)
+
+type Configuration struct {
+	Controls       ConfigurationControls           `yaml:"controls,omitempty"`
+	Contact        ConfigurationContact            `yaml:"contact,omitempty"`
+	Skills         []ConfigurationSkills           `yaml:"skills,omitempty"`
+	Employment     []ConfigurationOrganization     `yaml:"employment,omitempty"`
+	Volunteering   []ConfigurationOrganization     `yaml:"volunteering,omitempty"`
+	Politics       []ConfigurationOrganization     `yaml:"politics,omitempty"`
+	Education      []ConfigurationEducation        `yaml:"education,omitempty"`
+	Projects       []ConfigurationProject          `yaml:"projects,omitempty"`
+	Certifications []ConfigurationCertification    `yaml:"certifications,omitempty"`
+	Sections       map[string][]ConfigurationEntry `yaml:"sections,omitempty"`
+
+	// Sources maps the path of every value, such as contact.email_address, to
+	// the resume file it came from
+	Sources map[string]string `yaml:"-"`
+}
+
+type ConfigurationControls struct {
+	Renderer       string                                     `yaml:"renderer"`
+	Layout         ConfigurationControlsLayout                `yaml:"layout"`
+	Pdf            ConfigurationControlsPdf                   `yaml:"pdf"`
+	Text           ConfigurationControlsText                  `yaml:"text"`
+	Public         ConfigurationControlsPublic                `yaml:"public"`
+	Flavor         ConfigurationControlsFlavor                `yaml:"flavor"`
+	Skills         ConfigurationControlsSkills                `yaml:"skills"`
+	Employers      ConfigurationControlsOrganizations         `yaml:"employers"`
+	Volunteering   ConfigurationControlsOrganizations         `yaml:"volunteering"`
+	Politics       ConfigurationControlsOrganizations         `yaml:"politics"`
+	Education      ConfigurationControlCountTagged            `yaml:"education"`
+	Certifications ConfigurationControlCountTagged            `yaml:"certifications"`
+	Projects       ConfigurationControlCountTagged            `yaml:"projects"`
+	Sections       map[string]ConfigurationControlCountTagged `yaml:"sections"`
+}
+
+type ConfigurationControlsPdf struct {
+	Filename     string                               `yaml:"filename"`
+	Fonts        ConfigurationControlsPdfFonts        `yaml:"fonts"`
+	Margins      ConfigurationControlsPdfMargins      `yaml:"margins"`
+	Page         ConfigurationControlsPdfPage         `yaml:"page"`
+	KeepTogether ConfigurationControlsPdfKeepTogether `yaml:"keep_together"`
+	Keywords     []string                             `yaml:"keywords"`
+}
+
+// ConfigurationControlsPdfKeepTogether are the rules for what the PDF moves to
+// the next page together rather than splitting between pages
+//
+// A bullet point wrapped over several lines is kept whole, unless
+// bullet_point_lines allows splitting it with at least that many lines on
+// either page
+type ConfigurationControlsPdfKeepTogether struct {
+	SectionTitles       bool `yaml:"section_titles"`
+	OrganizationHeaders bool `yaml:"organization_headers"`
+	Positions           bool `yaml:"positions"`
+	BulletPointLines    uint `yaml:"bullet_point_lines"`
+}
+
+// DefaultKeepTogether applies to any controls which leave the rules out
+var DefaultKeepTogether = ConfigurationControlsPdfKeepTogether{
+	SectionTitles:       true,
+	OrganizationHeaders: true,
+	Positions:           true,
+}
+
+type ConfigurationControlsText struct {
+	Width int `yaml:"width"`
+}
+
+// ConfigurationControlsPublic tunes public documents, which leave out the email
+// address and phone number; the placeholder is shown in their place
+type ConfigurationControlsPublic struct {
+	Placeholder string `yaml:"placeholder"`
+}
+
+type ConfigurationControlsPdfFonts struct {
+	Header   string                                        `yaml:"header"`
+	Footer   string                                        `yaml:"footer"`
+	Default  string                                        `yaml:"default"`
+	Families map[string]ConfigurationControlsPdfFontFamily `yaml:"families"`
+}
+
+// ConfigurationControlsPdfFontFamily is a TrueType font family to embed; any
+// missing variant falls back to the regular one
+type ConfigurationControlsPdfFontFamily struct {
+	Regular    string `yaml:"regular"`
+	Bold       string `yaml:"bold"`
+	Italic     string `yaml:"italic"`
+	BoldItalic string `yaml:"bold_italic"`
+}
+
+// ConfigurationControlsPdfPage is either a named paper size, or a custom width
+// and height in millimeters; either is given in portrait and then turned for
+// landscape
+type ConfigurationControlsPdfPage struct {
+	Size        string  `yaml:"size"`
+	Width       float64 `yaml:"width"`
+	Height      float64 `yaml:"height"`
+	Orientation string  `yaml:"orientation"`
+}
+
+var (
+	PageOrientationPortrait  = "portrait"
+	PageOrientationLandscape = "landscape"
+)
+
+// PageMarginBottom is the bottom margin in millimeters, which is where the PDF
+// breaks pages automatically; the other formats use the same
+const PageMarginBottom = float64(20)
+
+// Paper sizes in portrait millimeters
+var pageSizes = map[string][2]float64{
+	"a3":      {297, 420},
+	"a4":      {210, 297},
+	"a5":      {148, 210},
+	"letter":  {215.9, 279.4},
+	"legal":   {215.9, 355.6},
+	"tabloid": {279.4, 431.8},
+}
+
+// dimensions is the width and height of the page in millimeters, as oriented;
+// letter portrait is the default
+func (pg *ConfigurationControlsPdfPage) dimensions() (float64, float64) {
+	width, height := pg.Width, pg.Height
+
+	if (width == 0) && (height == 0) {
+		size, exists := pageSizes[strings.ToLower(pg.Size)]
+
+		if !exists {
+			size = pageSizes["letter"]
+		}
+
+		width, height = size[0], size[1]
+	}
+
+	if strings.EqualFold(pg.Orientation, PageOrientationLandscape) {
+		return height, width
+	}
+
+	return width, height
+}
+
+func (pg *ConfigurationControlsPdfPage) validate() error {
+	if (pg.Width != 0) || (pg.Height != 0) {
+		if pg.Size != "" {
+			return fmt.Errorf("control pdf.page.size can't be used with a custom width and height: %s", pg.Size)
+		}
+
+		if (pg.Width <= 0) || (pg.Height <= 0) {
+			return fmt.Errorf("control pdf.page width and height must both be positive: %g x %g", pg.Width, pg.Height)
+		}
+	} else if _, exists := pageSizes[strings.ToLower(pg.Size)]; !exists && (pg.Size != "") {
+		return fmt.Errorf("control pdf.page.size value is invalid: %s", pg.Size)
+	}
+
+	if (pg.Orientation != "") && !strings.EqualFold(pg.Orientation, PageOrientationPortrait) && !strings.EqualFold(pg.Orientation, PageOrientationLandscape) {
+		return fmt.Errorf("control pdf.page.orientation value is invalid: %s", pg.Orientation)
+	}
+
+	return nil
+}
+
+type ConfigurationControlsPdfMargins struct {
+	Left  float64 `yaml:"left"`
+	Top   float64 `yaml:"top"`
+	Right float64 `yaml:"right"`
+}
+
+type ConfigurationControlsFlavor struct {
+	Header string `yaml:"header"`
+	Footer string `yaml:"footer"`
+}
+
+// ConfigurationControlsLayout orders the sections by name, such as
+// skills.first, employers.expanded, or education
+type ConfigurationControlsLayout struct {
+	Sections []string `yaml:"sections"`
+}
+
+// DefaultLayoutSections is the order of the sections when the controls don't
+// give one; any skills tiers beyond first, second, and third follow in name
+// order, and then the custom sections in name order
+var DefaultLayoutSections = []string{
+	"skills.first",
+	"skills.second",
+	"employers.expanded",
+	"employers.condensed",
+	"politics.expanded",
+	"politics.condensed",
+	"volunteering.expanded",
+	"volunteering.condensed",
+	"skills.third",
+	"education",
+	"projects",
+	"certifications",
+}
+
+// layoutSections is the order the sections are selected and rendered in
+func (cc *ConfigurationControls) layoutSections() []string {
+	if len(cc.Layout.Sections) > 0 {
+		return cc.Layout.Sections
+	}
+
+	sections := append([]string(nil), DefaultLayoutSections...)
+	tiers := make([]string, 0)
+
+	for tier := range cc.Skills {
+		switch tier {
+		case "first", "second", "third":
+		default:
+			tiers = append(tiers, "skills."+tier)
+		}
+	}
+
+	sort.Strings(tiers)
+
+	custom := make([]string, 0, len(cc.Sections))
+
+	for name := range cc.Sections {
+		custom = append(custom, "sections."+name)
+	}
+
+	sort.Strings(custom)
+
+	sections = append(sections, tiers...)
+
+	return append(sections, custom...)
+}
+
+// hasSection reports whether the name is one of the sections of the controls
+func (cc *ConfigurationControls) hasSection(name string) bool {
+	parts := strings.SplitN(name, ".", 2)
+
+	switch parts[0] {
+	case "skills":
+		if len(parts) == 2 {
+			_, exists := cc.Skills[parts[1]]
+
+			return exists
+		}
+	case "employers", "politics", "volunteering":
+		return (len(parts) == 2) && ((parts[1] == "expanded") || (parts[1] == "condensed"))
+	case "sections":
+		if len(parts) == 2 {
+			_, exists := cc.Sections[parts[1]]
+
+			return exists
+		}
+	case "education", "projects", "certifications":
+		return len(parts) == 1
+	}
+
+	return false
+}
+
+// ConfigurationControlsSkills are the skills tiers by name, each of which is a
+// section of its own
+type ConfigurationControlsSkills map[string]ConfigurationControlCountTagged
+
+type ConfigurationControlsOrganizations struct {
+	Expanded  ConfigurationControlsOrganizationExpanded  `yaml:"expanded"`
+	Condensed ConfigurationControlsOrganizationCondensed `yaml:"condensed"`
+}
+
+type ConfigurationControlsOrganizationExpanded struct {
+	Title                     string                                             `yaml:"title"`
+	Count                     uint                                               `yaml:"count"`
+	PositionsCount            uint                                               `yaml:"positions_count"`
+	BulletPoints              ConfigurationControlsEmployersExpandedBulletPoints `yaml:"bullet_points"`
+	CollapseMultiplePositions string                                             `yaml:"collapse_multiple_positions"`
+	Tags                      []string                                           `yaml:"tags"`
+	PositionTags              []string                                           `yaml:"position_tags"`
+	PageBreakBefore           string                                             `yaml:"page_break_before"`
+	PageBreakBeforeEntry      string                                             `yaml:"page_break_before_entry"`
+}
+
+type ConfigurationControlsOrganizationCondensed struct {
+	Title                     string   `yaml:"title"`
+	Count                     uint     `yaml:"count"`
+	PositionsCount            uint     `yaml:"positions_count"`
+	CollapseMultiplePositions string   `yaml:"collapse_multiple_positions"`
+	Tags                      []string `yaml:"tags"`
+	PositionTags              []string `yaml:"position_tags"`
+	PageBreakBefore           string   `yaml:"page_break_before"`
+	PageBreakBeforeEntry      string   `yaml:"page_break_before_entry"`
+}
+
+type ConfigurationControlsEmployersExpandedBulletPoints struct {
+	Start     uint `yaml:"start"`
+	Decrement uint `yaml:"decrement"`
+}
+
+type ConfigurationControlCountTagged struct {
+	Title           string   `yaml:"title"`
+	Count           uint     `yaml:"count"`
+	Tags            []string `yaml:"tags"`
+	PageBreakBefore string   `yaml:"page_break_before"`
+}
+
+type ConfigurationContact struct {
+	Name         string `yaml:"name,omitempty"`
+	Pronouns     string `yaml:"pronouns,omitempty"`
+	EmailAddress string `yaml:"email_address,omitempty"`
+	PhoneNumber  string `yaml:"phone_number,omitempty"`
+	Url          string `yaml:"url,omitempty"`
+	Repository   string `yaml:"repository,omitempty"`
+	Location     string `yaml:"location,omitempty"`
+}
+
+type ConfigurationSkills struct {
+	Id   string   `yaml:"id,omitempty"`
+	Name string   `yaml:"name,omitempty"`
+	Tags []string `yaml:"tags,omitempty"`
+}
+
+type ConfigurationOrganization struct {
+	Id                string                              `yaml:"id,omitempty"`
+	Organization      string                              `yaml:"organization,omitempty"`
+	OrganizationExtra string                              `yaml:"organization_extra,omitempty"`
+	Url               string                              `yaml:"url,omitempty"`
+	Location          string                              `yaml:"location,omitempty"`
+	Positions         []ConfigurationOrganizationPosition `yaml:"positions,omitempty"`
+	Tags              []string                            `yaml:"tags,omitempty"`
+}
+
+type ConfigurationOrganizationPosition struct {
+	Id              string             `yaml:"id,omitempty"`
+	Title           string             `yaml:"title,omitempty"`
+	NormalizedTitle string             `yaml:"normalized_title,omitempty"`
+	Flavor          string             `yaml:"flavor,omitempty"`
+	Summary         string             `yaml:"summary,omitempty"`
+	Dates           ConfigurationDates `yaml:"dates,omitempty"`
+	BulletPoints    []string           `yaml:"bullet_points,omitempty"`
+	Tags            []string           `yaml:"tags,omitempty"`
+}
+
+type ConfigurationEducation struct {
+	Id          string   `yaml:"id,omitempty"`
+	Title       string   `yaml:"title,omitempty"`
+	Url         string   `yaml:"url,omitempty"`
+	Institution string   `yaml:"institution,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+}
+
+type ConfigurationProject struct {
+	Id           string             `yaml:"id,omitempty"`
+	Title        string             `yaml:"title,omitempty"`
+	Url          string             `yaml:"url,omitempty"`
+	Location     string             `yaml:"location,omitempty"`
+	Role         string             `yaml:"role,omitempty"`
+	Summary      string             `yaml:"summary,omitempty"`
+	Dates        ConfigurationDates `yaml:"dates,omitempty"`
+	BulletPoints []string           `yaml:"bullet_points,omitempty"`
+	Tags         []string           `yaml:"tags,omitempty"`
+}
+
+type ConfigurationCertification struct {
+	Id            string             `yaml:"id,omitempty"`
+	Certification string             `yaml:"certification,omitempty"`
+	Url           string             `yaml:"url,omitempty"`
+	Authority     string             `yaml:"authority,omitempty"`
+	Credential    string             `yaml:"credential,omitempty"`
+	Dates         ConfigurationDates `yaml:"dates,omitempty"`
+	Tags          []string           `yaml:"tags,omitempty"`
+}
+
+// ConfigurationEntry is an entry of a custom section, such as an award, a
+// publication, or a talk
+type ConfigurationEntry struct {
+	Id           string             `yaml:"id,omitempty"`
+	Title        string             `yaml:"title,omitempty"`
+	Subtitle     string             `yaml:"subtitle,omitempty"`
+	Url          string             `yaml:"url,omitempty"`
+	Location     string             `yaml:"location,omitempty"`
+	Dates        ConfigurationDates `yaml:"dates,omitempty"`
+	BulletPoints []string           `yaml:"bullet_points,omitempty"`
+	Tags         []string           `yaml:"tags,omitempty"`
+}
+
+type ConfigurationDates struct {
+	Start string `yaml:"start,omitempty"`
+	End   string `yaml:"end,omitempty"`
+}
+
+// LoadConfiguration reads the base resume, secret resume, and controls files
+// into a single validated Configuration
+func LoadConfiguration(baseResumeFile, secretResumeFile, controlsFile string) (*Configuration, error) {
+	c, err := LoadResume(baseResumeFile, secretResumeFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	controls, err := LoadControls(controlsFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.WithControls(controls), nil
+}
+
+// LoadResume reads the base and secret resume files into a Configuration which
+// has no controls yet; the secret resume file is optional
+func LoadResume(baseResumeFile, secretResumeFile string) (*Configuration, error) {
+	return LoadResumeWithPassphrase(baseResumeFile, secretResumeFile, nil)
+}
+
+// LoadResumeWithPassphrase is LoadResume for a secret resume file which may be
+// encrypted with the passphrase
+func LoadResumeWithPassphrase(baseResumeFile, secretResumeFile string, passphrase []byte) (*Configuration, error) {
+	if _, err := os.Stat(secretResumeFile); errors.Is(err, fs.ErrNotExist) {
+		return LoadResumesWithPassphrase(passphrase, baseResumeFile)
+	}
+
+	return LoadResumesWithPassphrase(passphrase, baseResumeFile, secretResumeFile)
+}
+
+// LoadResumes merges the resume files in order into a Configuration which has
+// no controls yet; each file can add to any entry of the files before it
+// without repeating the rest of it
+func LoadResumes(resumeFiles ...string) (*Configuration, error) {
+	return LoadResumesWithPassphrase(nil, resumeFiles...)
+}
+
+// LoadResumesWithPassphrase is LoadResumes for resume files which may be
+// encrypted with the passphrase
+func LoadResumesWithPassphrase(passphrase []byte, resumeFiles ...string) (*Configuration, error) {
+	return loadResumes(newResumeMerger(passphrase), resumeFiles)
+}
+
+// LoadPublicResumes is LoadResumesWithPassphrase for a public document, leaving
+// out the secret resume files and any encrypted resume files, wherever they're
+// merged or included; none of their values are in the Configuration, and the
+// values they would have replaced are kept
+func LoadPublicResumes(passphrase []byte, secretResumeFiles []string, resumeFiles ...string) (*Configuration, error) {
+	m := newResumeMerger(passphrase)
+	m.public = true
+	m.secrets = make(map[string]bool)
+
+	for _, secretResumeFile := range secretResumeFiles {
+		path, err := filepath.Abs(secretResumeFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("error resolving secret resume file %s: %w", secretResumeFile, err)
+		}
+
+		m.secrets[path] = true
+	}
+
+	return loadResumes(m, resumeFiles)
+}
+
+func loadResumes(m *resumeMerger, resumeFiles []string) (*Configuration, error) {
+	for _, resumeFile := range resumeFiles {
+		if err := m.add(resumeFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var c Configuration
+
+	if m.document.Kind != 0 {
+		if err := m.document.Decode(&c); err != nil {
+			return nil, fmt.Errorf("error decoding merged resume YAML: %w", err)
+		}
+	}
+
+	c.Sources = m.provenance()
+
+	c.normalize()
+
+	return &c, nil
+}
+
+// LoadControls reads and validates a single controls file, along with any
+// controls files it extends
+func LoadControls(controlsFile string) (*ConfigurationControls, error) {
+	node, err := loadControlsNode(controlsFile, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var cc ConfigurationControls
+
+	// Decoding only overwrites what the file sets
+	cc.Pdf.KeepTogether = DefaultKeepTogether
+
+	if node != nil {
+		if err = node.Decode(&cc); err != nil {
+			return nil, fmt.Errorf("error decoding controls YAML: %w", err)
+		}
+	}
+
+	if err = cc.validate(); err != nil {
+		return nil, err
+	}
+
+	if err = cc.validateFontFamilies(); err != nil {
+		return nil, err
+	}
+
+	cc.normalize()
+
+	return &cc, nil
+}
+
+// WithControls returns a copy of the configuration using the given controls;
+// the resume entries are shared, since rendering never modifies them
+func (c *Configuration) WithControls(controls *ConfigurationControls) *Configuration {
+	variant := *c
+	variant.Controls = *controls
+
+	return &variant
+}
+
+func (cc *ConfigurationControls) validate() error {
+	// Invalid values (invalues?!) checking
+	var validCollapseMultiplePositions = []string{
+		CollapseMultiplePositionsCollapse,
+		CollapseMultiplePositionsTitlesOnly,
+		CollapseMultiplePositionsFull,
+	}
+
+	sort.Strings(validCollapseMultiplePositions)
+
+	i := sort.SearchStrings(validCollapseMultiplePositions, cc.Employers.Expanded.CollapseMultiplePositions)
+
+	if (i >= len(validCollapseMultiplePositions)) || (validCollapseMultiplePositions[i] != cc.Employers.Expanded.CollapseMultiplePositions) {
+		return fmt.Errorf("control employers.expanded.collapse_multiple_positions value is invalid: %s", cc.Employers.Expanded.CollapseMultiplePositions)
+	}
+
+	if err := cc.Pdf.Page.validate(); err != nil {
+		return err
+	}
+
+	pageBreaks := map[string]string{
+		"employers.expanded.page_break_before":           cc.Employers.Expanded.PageBreakBefore,
+		"employers.expanded.page_break_before_entry":     cc.Employers.Expanded.PageBreakBeforeEntry,
+		"employers.condensed.page_break_before":          cc.Employers.Condensed.PageBreakBefore,
+		"employers.condensed.page_break_before_entry":    cc.Employers.Condensed.PageBreakBeforeEntry,
+		"volunteering.expanded.page_break_before":        cc.Volunteering.Expanded.PageBreakBefore,
+		"volunteering.expanded.page_break_before_entry":  cc.Volunteering.Expanded.PageBreakBeforeEntry,
+		"volunteering.condensed.page_break_before":       cc.Volunteering.Condensed.PageBreakBefore,
+		"volunteering.condensed.page_break_before_entry": cc.Volunteering.Condensed.PageBreakBeforeEntry,
+		"politics.expanded.page_break_before":            cc.Politics.Expanded.PageBreakBefore,
+		"politics.expanded.page_break_before_entry":      cc.Politics.Expanded.PageBreakBeforeEntry,
+		"politics.condensed.page_break_before":           cc.Politics.Condensed.PageBreakBefore,
+		"politics.condensed.page_break_before_entry":     cc.Politics.Condensed.PageBreakBeforeEntry,
+		"education.page_break_before":                    cc.Education.PageBreakBefore,
+		"projects.page_break_before":                     cc.Projects.PageBreakBefore,
+		"certifications.page_break_before":               cc.Certifications.PageBreakBefore,
+	}
+
+	for tier, control := range cc.Skills {
+		pageBreaks["skills."+tier+".page_break_before"] = control.PageBreakBefore
+	}
+
+	for name, control := range cc.Sections {
+		pageBreaks["sections."+name+".page_break_before"] = control.PageBreakBefore
+	}
+
+	for control, pageBreak := range pageBreaks {
+		switch pageBreak {
+		case "", PageBreakAlways, PageBreakAuto, PageBreakNever:
+		default:
+			return fmt.Errorf("control %s value is invalid: %s", control, pageBreak)
+		}
+	}
+
+	layoutSections := make(map[string]bool)
+
+	for _, name := range cc.Layout.Sections {
+		if !cc.hasSection(name) {
+			return fmt.Errorf("control layout.sections names an unknown section: %s", name)
+		}
+
+		if layoutSections[name] {
+			return fmt.Errorf("control layout.sections names a section twice: %s", name)
+		}
+
+		layoutSections[name] = true
+	}
+
+	if cc.Text.Width < 0 {
+		return fmt.Errorf("control text.width value is invalid: %d", cc.Text.Width)
+	}
+
+	return nil
+}
+
+// validateFontFamilies checks that the font files exist before any rendering
+// starts; they're already resolved against the controls files declaring them
+func (cc *ConfigurationControls) validateFontFamilies() error {
+	for family, files := range cc.Pdf.Fonts.Families {
+		if files.Regular == "" {
+			return fmt.Errorf("control pdf.fonts.families.%s.regular is missing", family)
+		}
+
+		for _, file := range []string{files.Regular, files.Bold, files.Italic, files.BoldItalic} {
+			if file == "" {
+				continue
+			}
+
+			if _, err := os.Stat(file); err != nil {
+				return fmt.Errorf("error finding font file for family %s: %w", family, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Replace newlines with single spaces for expected possible multiline fields
+var multilineReplacer = strings.NewReplacer(
+	"\n\r", " ",
+	"\n", " ",
+	"\r", " ",
+)
+
+func (cc *ConfigurationControls) normalize() {
+	cc.Flavor.Header = strings.TrimSpace(multilineReplacer.Replace(cc.Flavor.Header))
+	cc.Flavor.Footer = strings.TrimSpace(multilineReplacer.Replace(cc.Flavor.Footer))
+	cc.Public.Placeholder = strings.TrimSpace(multilineReplacer.Replace(cc.Public.Placeholder))
+}
+
+func (c *Configuration) normalize() {
+	for ei := range c.Employment {
+		for pi := range c.Employment[ei].Positions {
+			c.Employment[ei].Positions[pi].Summary = strings.TrimSpace(multilineReplacer.Replace(c.Employment[ei].Positions[pi].Summary))
+			c.Employment[ei].Positions[pi].Flavor = strings.TrimSpace(multilineReplacer.Replace(c.Employment[ei].Positions[pi].Flavor))
+
+			for bpi := range c.Employment[ei].Positions[pi].BulletPoints {
+				c.Employment[ei].Positions[pi].BulletPoints[bpi] = strings.TrimSpace(multilineReplacer.Replace(c.Employment[ei].Positions[pi].BulletPoints[bpi]))
+			}
+		}
+	}
+
+	for vi := range c.Volunteering {
+		for pi := range c.Volunteering[vi].Positions {
+			c.Volunteering[vi].Positions[pi].Summary = strings.TrimSpace(multilineReplacer.Replace(c.Volunteering[vi].Positions[pi].Summary))
+			c.Volunteering[vi].Positions[pi].Flavor = strings.TrimSpace(multilineReplacer.Replace(c.Volunteering[vi].Positions[pi].Flavor))
+
+			for bpi := range c.Volunteering[vi].Positions[pi].BulletPoints {
+				c.Volunteering[vi].Positions[pi].BulletPoints[bpi] = strings.TrimSpace(multilineReplacer.Replace(c.Volunteering[vi].Positions[pi].BulletPoints[bpi]))
+			}
+		}
+	}
+
+	for pi := range c.Politics {
+		for ppi := range c.Politics[pi].Positions {
+			c.Politics[pi].Positions[ppi].Summary = strings.TrimSpace(multilineReplacer.Replace(c.Politics[pi].Positions[ppi].Summary))
+			c.Politics[pi].Positions[ppi].Flavor = strings.TrimSpace(multilineReplacer.Replace(c.Politics[pi].Positions[ppi].Flavor))
+
+			for bpi := range c.Politics[pi].Positions[ppi].BulletPoints {
+				c.Politics[pi].Positions[ppi].BulletPoints[bpi] = strings.TrimSpace(multilineReplacer.Replace(c.Politics[pi].Positions[ppi].BulletPoints[bpi]))
+			}
+		}
+	}
+
+	for pi := range c.Projects {
+		c.Projects[pi].Summary = strings.TrimSpace(multilineReplacer.Replace(c.Projects[pi].Summary))
+
+		for bpi := range c.Projects[pi].BulletPoints {
+			c.Projects[pi].BulletPoints[bpi] = strings.TrimSpace(multilineReplacer.Replace(c.Projects[pi].BulletPoints[bpi]))
+		}
+	}
+
+	for name := range c.Sections {
+		for ei := range c.Sections[name] {
+			for bpi := range c.Sections[name][ei].BulletPoints {
+				c.Sections[name][ei].BulletPoints[bpi] = strings.TrimSpace(multilineReplacer.Replace(c.Sections[name][ei].BulletPoints[bpi]))
+			}
+		}
+	}
+}
+
+// EncodeResume writes the configuration as a resume YAML file, leaving out the
+// controls and any empty fields
+func EncodeResume(w io.Writer, c *Configuration) error {
+	resume := *c
+	resume.Controls = ConfigurationControls{}
+
+	if _, err := io.WriteString(w, "---\n\n"); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&resume); err != nil {
+		return fmt.Errorf("error encoding resume YAML: %w", err)
+	}
+
+	return encoder.Close()
+}
@@ -0,0 +1,130 @@
+package resume
+
+import (
+	"testing"
+)
+
+// validControls is the least the controls need to set to be valid
+func validControls() ConfigurationControls {
+	var cc ConfigurationControls
+
+	cc.Employers.Expanded.CollapseMultiplePositions = CollapseMultiplePositionsFull
+
+	return cc
+}
+
+func TestValidatePage(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    ConfigurationControlsPdfPage
+		wantErr bool
+	}{
+		{"default", ConfigurationControlsPdfPage{}, false},
+		{"named size", ConfigurationControlsPdfPage{Size: "a4"}, false},
+		{"named size in any case", ConfigurationControlsPdfPage{Size: "Letter", Orientation: "Landscape"}, false},
+		{"custom size", ConfigurationControlsPdfPage{Width: 100, Height: 150.5}, false},
+		{"unknown size", ConfigurationControlsPdfPage{Size: "b5"}, true},
+		{"named and custom size", ConfigurationControlsPdfPage{Size: "a4", Width: 100, Height: 150}, true},
+		{"custom width only", ConfigurationControlsPdfPage{Width: 100}, true},
+		{"negative custom size", ConfigurationControlsPdfPage{Width: 100, Height: -150}, true},
+		{"orientation", ConfigurationControlsPdfPage{Orientation: "portrait"}, false},
+		{"unknown orientation", ConfigurationControlsPdfPage{Orientation: "sideways"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cc := validControls()
+			cc.Pdf.Page = test.page
+
+			if err := cc.validate(); (err != nil) != test.wantErr {
+				t.Errorf("validate() error = %v, want an error: %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestPageDimensions(t *testing.T) {
+	tests := []struct {
+		page       ConfigurationControlsPdfPage
+		wantWidth  float64
+		wantHeight float64
+	}{
+		{ConfigurationControlsPdfPage{}, 215.9, 279.4},
+		{ConfigurationControlsPdfPage{Size: "A4"}, 210, 297},
+		{ConfigurationControlsPdfPage{Size: "a5", Orientation: "landscape"}, 210, 148},
+		{ConfigurationControlsPdfPage{Width: 100, Height: 150, Orientation: "Landscape"}, 150, 100},
+	}
+
+	for _, test := range tests {
+		if width, height := test.page.dimensions(); (width != test.wantWidth) || (height != test.wantHeight) {
+			t.Errorf("%+v dimensions() = %g x %g, want %g x %g", test.page, width, height, test.wantWidth, test.wantHeight)
+		}
+	}
+}
+
+func TestValidateLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections []string
+		wantErr  bool
+	}{
+		{"default", nil, false},
+		{"every kind of section", []string{"skills.community", "employers.expanded", "politics.condensed", "volunteering.expanded", "education", "projects", "certifications", "sections.awards"}, false},
+		{"unknown skills tier", []string{"skills.fourth"}, true},
+		{"skills without a tier", []string{"skills"}, true},
+		{"organizations without a kind", []string{"employers"}, true},
+		{"unknown organizations kind", []string{"employers.collapsed"}, true},
+		{"unknown custom section", []string{"sections.talks"}, true},
+		{"education with a tier", []string{"education.first"}, true},
+		{"unknown section", []string{"hobbies"}, true},
+		{"section twice", []string{"education", "employers.expanded", "education"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cc := validControls()
+			cc.Skills = ConfigurationControlsSkills{"community": {Count: 6}}
+			cc.Sections = map[string]ConfigurationControlCountTagged{"awards": {Count: 3}}
+			cc.Layout.Sections = test.sections
+
+			if err := cc.validate(); (err != nil) != test.wantErr {
+				t.Errorf("validate() error = %v, want an error: %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePageBreaks(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cc *ConfigurationControls)
+		wantErr bool
+	}{
+		{"default", func(cc *ConfigurationControls) {}, false},
+		{"always before a section", func(cc *ConfigurationControls) { cc.Education.PageBreakBefore = PageBreakAlways }, false},
+		{"auto before a skills tier", func(cc *ConfigurationControls) {
+			cc.Skills = ConfigurationControlsSkills{"first": {PageBreakBefore: PageBreakAuto}}
+		}, false},
+		{"never before each organization", func(cc *ConfigurationControls) { cc.Politics.Condensed.PageBreakBeforeEntry = PageBreakNever }, false},
+		{"invalid before a section", func(cc *ConfigurationControls) { cc.Certifications.PageBreakBefore = "sometimes" }, true},
+		{"invalid before an organizations section", func(cc *ConfigurationControls) { cc.Employers.Condensed.PageBreakBefore = "Always" }, true},
+		{"invalid before each organization", func(cc *ConfigurationControls) { cc.Volunteering.Expanded.PageBreakBeforeEntry = "true" }, true},
+		{"invalid before a skills tier", func(cc *ConfigurationControls) {
+			cc.Skills = ConfigurationControlsSkills{"community": {PageBreakBefore: "yes"}}
+		}, true},
+		{"invalid before a custom section", func(cc *ConfigurationControls) {
+			cc.Sections = map[string]ConfigurationControlCountTagged{"awards": {PageBreakBefore: "no"}}
+		}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cc := validControls()
+			test.modify(&cc)
+
+			if err := cc.validate(); (err != nil) != test.wantErr {
+				t.Errorf("validate() error = %v, want an error: %t", err, test.wantErr)
+			}
+		})
+	}
+}
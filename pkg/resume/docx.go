@@ -0,0 +1,479 @@
+package resume
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterRenderer("docx", DefaultRenderer, classicDocxRenderer{})
+}
+
+// classicDocxRenderer writes an Office Open XML word processing package in the
+// style of the classic PDF, using Word's own headings, bullet lists, and
+// hyperlinks so that the document stays editable
+type classicDocxRenderer struct{}
+
+func (classicDocxRenderer) Render(p *Plan, w io.Writer, o Options) error {
+	return newDocxDocument(p, o).render(w)
+}
+
+func (classicDocxRenderer) Extension() string {
+	return "docx"
+}
+
+// docxFonts maps the PDF core font names to the fonts Word ships with
+var docxFonts = map[string]string{
+	"arial":     "Arial",
+	"helvetica": "Arial",
+	"times":     "Times New Roman",
+	"courier":   "Courier New",
+}
+
+func docxFont(font string) string {
+	if mapped, exists := docxFonts[strings.ToLower(font)]; exists {
+		return mapped
+	}
+
+	return font
+}
+
+// docxMillimeters converts PDF measurements to twentieths of a point
+func docxMillimeters(mm float64) int {
+	return int(math.Round(mm * 1440 / 25.4))
+}
+
+// docxDocument carries the state of a single DOCX render: the body as it is
+// written, and the relationships of the hyperlinks within it
+type docxDocument struct {
+	p            *Plan
+	timestamp    time.Time
+	body         bytes.Buffer
+	hyperlinks   []string
+	pageWidth    int
+	pageHeight   int
+	marginLeft   int
+	marginRight  int
+	marginTop    int
+	marginBottom int
+}
+
+func newDocxDocument(p *Plan, o Options) *docxDocument {
+	pageWidth, pageHeight := p.Controls.Pdf.Page.dimensions()
+
+	return &docxDocument{
+		p:            p,
+		timestamp:    o.timestamp(),
+		pageWidth:    docxMillimeters(pageWidth),
+		pageHeight:   docxMillimeters(pageHeight),
+		marginLeft:   docxMillimeters(p.Controls.Pdf.Margins.Left),
+		marginRight:  docxMillimeters(p.Controls.Pdf.Margins.Right),
+		marginTop:    docxMillimeters(p.Controls.Pdf.Margins.Top),
+		marginBottom: docxMillimeters(PageMarginBottom),
+	}
+}
+
+func (d *docxDocument) orientation() string {
+	if d.pageWidth > d.pageHeight {
+		return PageOrientationLandscape
+	}
+
+	return PageOrientationPortrait
+}
+
+// tabStop is the position of the right aligned tab used for locations, dates,
+// and the like
+func (d *docxDocument) tabStop() int {
+	return d.pageWidth - d.marginLeft - d.marginRight
+}
+
+func (d *docxDocument) render(w io.Writer) error {
+	p := d.p
+
+	d.paragraph("Title", false)
+	d.run(p.Contact.Name, false, false)
+	d.tab()
+	d.run(p.Controls.Flavor.Header, false, true)
+	d.endParagraph()
+
+	d.docxContactLine()
+
+	for i := range p.Sections {
+		s := &p.Sections[i]
+
+		d.paragraph("Heading1", false)
+		d.run(s.Title, false, false)
+		d.endParagraph()
+
+		switch s.Kind {
+		case SectionSkills:
+			d.paragraph("Skills", false)
+			d.run(strings.Join(s.Skills, " / "), false, false)
+			d.endParagraph()
+		case SectionOrganizations:
+			d.docxOrganizationalExperience(s)
+		case SectionEducation:
+			for _, education := range s.Education {
+				d.paragraph("", true)
+				d.link(education.Title, education.Url, true, false)
+				d.tab()
+				d.run(education.Institution, false, true)
+				d.endParagraph()
+			}
+		case SectionProjects:
+			for _, project := range s.Projects {
+				d.paragraph("Heading2", true)
+				d.link(project.Title, project.Url, false, false)
+				d.tab()
+				d.run(project.Location, false, false)
+				d.endParagraph()
+
+				d.paragraph("Position", true)
+				d.run(project.Role, false, false)
+				d.tab()
+				d.run(project.Dates.Start+" to "+project.Dates.End, false, false)
+				d.endParagraph()
+
+				d.docxDetails(project.Summary, project.BulletPoints)
+			}
+		case SectionCertifications:
+			for _, certification := range s.Certifications {
+				d.paragraph("", true)
+				d.link(certification.Certification, certification.Url, true, false)
+				d.run(" ("+certification.Dates.Start+"-"+certification.Dates.End+")", false, false)
+				d.tab()
+				d.run(certification.Authority, false, true)
+				d.endParagraph()
+			}
+		case SectionCustom:
+			for _, entry := range s.Entries {
+				d.paragraph("", true)
+				d.link(entry.Title, entry.Url, true, false)
+
+				if dates := entryDates(entry.Dates); dates != "" {
+					d.run(" ("+dates+")", false, false)
+				}
+
+				d.tab()
+
+				details := make([]string, 0, 2)
+
+				for _, detail := range []string{entry.Subtitle, entry.Location} {
+					if detail != "" {
+						details = append(details, detail)
+					}
+				}
+
+				d.run(strings.Join(details, ", "), false, true)
+				d.endParagraph()
+
+				d.docxDetails("", entry.BulletPoints)
+			}
+		}
+	}
+
+	return d.write(w)
+}
+
+func (d *docxDocument) docxContactLine() {
+	p := d.p
+
+	d.paragraph("Contact", false)
+
+	separate := false
+
+	for _, contact := range []struct {
+		text string
+		url  string
+	}{
+		{p.Contact.Pronouns, ""},
+		{p.Contact.EmailAddress, "mailto:" + p.Contact.EmailAddress},
+		{p.Withheld, ""},
+		{p.Contact.PhoneNumber, phoneNumberUrl(p.Contact.PhoneNumber)},
+		{p.Contact.Url, p.Contact.Url},
+		{p.Contact.Location, ""},
+	} {
+		if contact.text == "" {
+			continue
+		}
+
+		if separate {
+			d.run(" | ", false, false)
+		}
+
+		d.link(contact.text, contact.url, false, false)
+
+		separate = true
+	}
+
+	d.endParagraph()
+}
+
+func (d *docxDocument) docxOrganizationalExperience(s *PlanSection) {
+	for _, organization := range s.Organizations {
+		d.paragraph("Heading2", true)
+		d.link(organization.Organization, organization.Url, false, false)
+
+		if organization.OrganizationExtra != "" {
+			d.run(" ("+organization.OrganizationExtra+")", false, false)
+		}
+
+		d.tab()
+		d.run(organization.Location, false, false)
+		d.endParagraph()
+
+		s.EachPosition(&organization, d.docxPositionTitleLine, func(position *PlanPosition) {
+			d.docxDetails(position.Summary, position.BulletPoints)
+		})
+	}
+}
+
+func (d *docxDocument) docxPositionTitleLine(position *PlanPosition) {
+	d.paragraph("Position", true)
+	d.run(position.Title, false, false)
+
+	if position.Flavor != "" {
+		d.run(" - "+position.Flavor, false, true)
+	}
+
+	d.tab()
+	d.run(position.Dates.Start+" to "+position.Dates.End, false, false)
+	d.endParagraph()
+}
+
+func (d *docxDocument) docxDetails(summary string, bulletPoints []string) {
+	if summary != "" {
+		d.paragraph("", false)
+		d.run(summary, false, false)
+		d.endParagraph()
+	}
+
+	for _, bulletPoint := range bulletPoints {
+		d.paragraph("ListBullet", false)
+		d.run(bulletPoint, false, false)
+		d.endParagraph()
+	}
+}
+
+// paragraph starts a paragraph in the style, which may be empty for the normal
+// style, optionally with a tab stop at the right margin
+func (d *docxDocument) paragraph(style string, tabbed bool) {
+	d.body.WriteString("<w:p>")
+
+	if (style != "") || tabbed {
+		d.body.WriteString("<w:pPr>")
+
+		if style != "" {
+			fmt.Fprintf(&d.body, `<w:pStyle w:val="%s"/>`, style)
+		}
+
+		if tabbed {
+			fmt.Fprintf(&d.body, `<w:tabs><w:tab w:val="right" w:pos="%d"/></w:tabs>`, d.tabStop())
+		}
+
+		d.body.WriteString("</w:pPr>")
+	}
+}
+
+func (d *docxDocument) endParagraph() {
+	d.body.WriteString("</w:p>")
+}
+
+func (d *docxDocument) tab() {
+	d.body.WriteString("<w:r><w:tab/></w:r>")
+}
+
+func (d *docxDocument) run(text string, bold, italic bool) {
+	d.styledRun(text, "", bold, italic)
+}
+
+func (d *docxDocument) styledRun(text, style string, bold, italic bool) {
+	if text == "" {
+		return
+	}
+
+	d.body.WriteString("<w:r>")
+
+	if (style != "") || bold || italic {
+		d.body.WriteString("<w:rPr>")
+
+		if style != "" {
+			fmt.Fprintf(&d.body, `<w:rStyle w:val="%s"/>`, style)
+		}
+
+		if bold {
+			d.body.WriteString("<w:b/>")
+		}
+
+		if italic {
+			d.body.WriteString("<w:i/>")
+		}
+
+		d.body.WriteString("</w:rPr>")
+	}
+
+	d.body.WriteString(`<w:t xml:space="preserve">`)
+	docxEscape(&d.body, text)
+	d.body.WriteString("</w:t></w:r>")
+}
+
+// link writes the text as a hyperlink run, whose URL is listed among the
+// document relationships, or as a plain run without a URL
+func (d *docxDocument) link(text, url string, bold, italic bool) {
+	if (url == "") || (text == "") {
+		d.run(text, bold, italic)
+
+		return
+	}
+
+	d.hyperlinks = append(d.hyperlinks, url)
+
+	fmt.Fprintf(&d.body, `<w:hyperlink r:id="rIdLink%d">`, len(d.hyperlinks))
+	d.styledRun(text, "Hyperlink", bold, italic)
+	d.body.WriteString("</w:hyperlink>")
+}
+
+func docxEscape(w io.Writer, s string) {
+	// Writing to a buffer never fails
+	_ = xml.EscapeText(w, []byte(s))
+}
+
+func docxEscapeString(s string) string {
+	var b bytes.Buffer
+
+	docxEscape(&b, s)
+
+	return b.String()
+}
+
+// write packages the body and its parts into the zip container Word expects
+func (d *docxDocument) write(w io.Writer) error {
+	p := d.p
+
+	var relationships bytes.Buffer
+
+	for i, url := range d.hyperlinks {
+		fmt.Fprintf(&relationships, `<Relationship Id="rIdLink%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="%s" TargetMode="External"/>`, i+1, docxEscapeString(url))
+	}
+
+	defaultFont := docxEscapeString(docxFont(p.Controls.Pdf.Fonts.Default))
+	headerFont := docxEscapeString(docxFont(p.Controls.Pdf.Fonts.Header))
+	timestamp := d.timestamp.UTC().Format("2006-01-02T15:04:05Z")
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxPackageRelationships},
+		{"docProps/core.xml", fmt.Sprintf(docxCoreProperties,
+			docxEscapeString(p.Contact.Name+"'s Resume"),
+			docxEscapeString(p.Contact.Name),
+			docxEscapeString(strings.Join(p.Controls.Pdf.Keywords, " ")),
+			timestamp,
+			timestamp,
+		)},
+		{"word/_rels/document.xml.rels", fmt.Sprintf(docxDocumentRelationships, relationships.String())},
+		{"word/styles.xml", fmt.Sprintf(docxStyles, defaultFont, headerFont, d.tabStop())},
+		{"word/numbering.xml", docxNumbering},
+		{"word/document.xml", fmt.Sprintf(docxDocumentBody,
+			d.body.String(),
+			d.pageWidth,
+			d.pageHeight,
+			d.orientation(),
+			d.marginTop,
+			d.marginRight,
+			d.marginBottom,
+			d.marginLeft,
+		)},
+	}
+
+	z := zip.NewWriter(w)
+
+	for _, part := range parts {
+		f, err := z.CreateHeader(&zip.FileHeader{
+			Name:     part.name,
+			Method:   zip.Deflate,
+			Modified: d.timestamp,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error creating DOCX part %s: %w", part.name, err)
+		}
+
+		if _, err = io.WriteString(f, part.content); err != nil {
+			return fmt.Errorf("error writing DOCX part %s: %w", part.name, err)
+		}
+	}
+
+	return z.Close()
+}
+
+const docxXmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+const docxContentTypes = docxXmlHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+<Override PartName="/word/numbering.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"/>
+<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>`
+
+const docxPackageRelationships = docxXmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>`
+
+const docxCoreProperties = docxXmlHeader + `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+<dc:title>%s</dc:title>
+<dc:creator>%s</dc:creator>
+<cp:keywords>%s</cp:keywords>
+<dcterms:created xsi:type="dcterms:W3CDTF">%s</dcterms:created>
+<dcterms:modified xsi:type="dcterms:W3CDTF">%s</dcterms:modified>
+</cp:coreProperties>`
+
+const docxDocumentRelationships = docxXmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+<Relationship Id="rIdNumbering" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"/>
+%s
+</Relationships>`
+
+const docxDocumentBody = docxXmlHeader + `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<w:body>
+%s
+<w:sectPr><w:pgSz w:w="%d" w:h="%d" w:orient="%s"/><w:pgMar w:top="%d" w:right="%d" w:bottom="%d" w:left="%d" w:header="0" w:footer="0" w:gutter="0"/></w:sectPr>
+</w:body>
+</w:document>`
+
+// The heading styles keep with the next paragraph, so that a title is never
+// left alone at the bottom of a page
+const docxStyles = docxXmlHeader + `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults>
+<w:rPrDefault><w:rPr><w:rFonts w:ascii="%[1]s" w:hAnsi="%[1]s" w:cs="%[1]s"/><w:sz w:val="22"/><w:szCs w:val="22"/><w:lang w:val="en-US"/></w:rPr></w:rPrDefault>
+<w:pPrDefault><w:pPr><w:spacing w:after="60" w:line="240" w:lineRule="auto"/></w:pPr></w:pPrDefault>
+</w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>
+<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:tabs><w:tab w:val="right" w:pos="%[3]d"/></w:tabs><w:spacing w:after="120"/></w:pPr><w:rPr><w:rFonts w:ascii="%[2]s" w:hAnsi="%[2]s" w:cs="%[2]s"/><w:b/><w:i/><w:sz w:val="36"/><w:szCs w:val="36"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Contact"><w:name w:val="Contact"/><w:basedOn w:val="Normal"/><w:qFormat/><w:pPr><w:jc w:val="center"/></w:pPr><w:rPr><w:sz w:val="18"/><w:szCs w:val="18"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:shd w:val="clear" w:color="auto" w:fill="C8C8C8"/><w:spacing w:before="360" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:sz w:val="28"/><w:szCs w:val="28"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="200" w:after="0"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:i/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Position"><w:name w:val="Position"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/></w:pPr><w:rPr><w:b/><w:sz w:val="24"/><w:szCs w:val="24"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Skills"><w:name w:val="Skills"/><w:basedOn w:val="Normal"/><w:qFormat/><w:pPr><w:jc w:val="center"/></w:pPr></w:style>
+<w:style w:type="paragraph" w:styleId="ListBullet"><w:name w:val="List Bullet"/><w:basedOn w:val="Normal"/><w:qFormat/><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr><w:spacing w:after="0"/></w:pPr></w:style>
+<w:style w:type="character" w:styleId="Hyperlink"><w:name w:val="Hyperlink"/><w:rPr><w:color w:val="0563C1"/><w:u w:val="single"/></w:rPr></w:style>
+</w:styles>`
+
+const docxNumbering = docxXmlHeader + `<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:abstractNum w:abstractNumId="0">
+<w:multiLevelType w:val="singleLevel"/>
+<w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="•"/><w:lvlJc w:val="left"/><w:pPr><w:ind w:left="360" w:hanging="360"/></w:pPr></w:lvl>
+</w:abstractNum>
+<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>
+</w:numbering>`
@@ -0,0 +1,173 @@
+package resume
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Encrypted resume files are PEM blocks, so they sit in git as plain text: the
+// key is derived from the passphrase with PBKDF2-HMAC-SHA256, and the resume is
+// sealed with AES-256-GCM
+const (
+	encryptedResumeType       = "RESUME SECRET"
+	encryptedResumeKdf        = "pbkdf2-sha256"
+	encryptedResumeCipher     = "aes-256-gcm"
+	encryptedResumeSaltSize   = 16
+	encryptedResumeKeySize    = 32
+	encryptedResumeIterations = 600000
+)
+
+// ErrPassphrase is returned when an encrypted resume file can't be decrypted
+// with the passphrase given, or no passphrase was given at all
+var ErrPassphrase = errors.New("wrong or missing passphrase")
+
+// IsEncryptedResume reports whether the resume file body was encrypted by
+// EncryptResume
+func IsEncryptedResume(body []byte) bool {
+	block, _ := pem.Decode(body)
+
+	return (block != nil) && (block.Type == encryptedResumeType)
+}
+
+// EncryptResume encrypts a resume file body with the passphrase
+func EncryptResume(body, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrPassphrase
+	}
+
+	salt := make([]byte, encryptedResumeSaltSize)
+
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("error generating salt: %w", err)
+	}
+
+	aead, err := newResumeCipher(passphrase, salt, encryptedResumeIterations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	sealed := append(salt, nonce...)
+	sealed = aead.Seal(sealed, nonce, body, nil)
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type: encryptedResumeType,
+		Headers: map[string]string{
+			"Kdf":        encryptedResumeKdf,
+			"Iterations": strconv.Itoa(encryptedResumeIterations),
+			"Cipher":     encryptedResumeCipher,
+		},
+		Bytes: sealed,
+	}), nil
+}
+
+// DecryptResume decrypts a resume file body encrypted by EncryptResume
+func DecryptResume(body, passphrase []byte) ([]byte, error) {
+	block, _ := pem.Decode(body)
+
+	if (block == nil) || (block.Type != encryptedResumeType) {
+		return nil, errors.New("not an encrypted resume file")
+	}
+
+	if (block.Headers["Kdf"] != encryptedResumeKdf) || (block.Headers["Cipher"] != encryptedResumeCipher) {
+		return nil, fmt.Errorf("unsupported encryption %s with %s", block.Headers["Cipher"], block.Headers["Kdf"])
+	}
+
+	iterations, err := strconv.Atoi(block.Headers["Iterations"])
+
+	if (err != nil) || (iterations < 1) {
+		return nil, fmt.Errorf("invalid iterations: %s", block.Headers["Iterations"])
+	}
+
+	if len(passphrase) == 0 {
+		return nil, ErrPassphrase
+	}
+
+	if len(block.Bytes) < encryptedResumeSaltSize {
+		return nil, errors.New("encrypted resume file is truncated")
+	}
+
+	salt, sealed := block.Bytes[:encryptedResumeSaltSize], block.Bytes[encryptedResumeSaltSize:]
+
+	aead, err := newResumeCipher(passphrase, salt, iterations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("encrypted resume file is truncated")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+
+	// Either the passphrase is wrong, or the file was tampered with
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+
+	return plaintext, nil
+}
+
+func newResumeCipher(passphrase, salt []byte, iterations int) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2Sha256(passphrase, salt, iterations, encryptedResumeKeySize))
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2Sha256 derives a key from the passphrase as in RFC 8018, which the
+// standard library doesn't offer as of the Go version this module targets
+func pbkdf2Sha256(passphrase, salt []byte, iterations, keySize int) []byte {
+	prf := hmac.New(sha256.New, passphrase)
+
+	var key bytes.Buffer
+
+	for blockIndex := uint32(1); key.Len() < keySize; blockIndex++ {
+		var counter [4]byte
+
+		binary.BigEndian.PutUint32(counter[:], blockIndex)
+
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter[:])
+
+		u := prf.Sum(nil)
+		t := append([]byte(nil), u...)
+
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+
+			u = prf.Sum(u[:0])
+
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+
+		key.Write(t)
+	}
+
+	return key.Bytes()[:keySize]
+}
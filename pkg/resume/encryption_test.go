@@ -0,0 +1,112 @@
+package resume
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestPbkdf2Sha256(t *testing.T) {
+	// From RFC 7914, section 11
+	tests := []struct {
+		passphrase string
+		salt       string
+		iterations int
+		want       string
+	}{
+		{"passwd", "salt", 1, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"Password", "NaCl", 80000, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+	}
+
+	for _, test := range tests {
+		got := hex.EncodeToString(pbkdf2Sha256([]byte(test.passphrase), []byte(test.salt), test.iterations, (len(test.want) / 2)))
+
+		if got != test.want {
+			t.Errorf("pbkdf2Sha256(%q, %q, %d) = %s, want %s", test.passphrase, test.salt, test.iterations, got, test.want)
+		}
+	}
+}
+
+func TestEncryptResume(t *testing.T) {
+	body := []byte("contact:\n  email_address: private@example.com\n")
+	passphrase := []byte("correct horse battery staple")
+
+	encrypted, err := EncryptResume(body, passphrase)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsEncryptedResume(encrypted) || IsEncryptedResume(body) {
+		t.Error("IsEncryptedResume() doesn't tell encrypted and plain resume files apart")
+	}
+
+	if bytes.Contains(encrypted, []byte("private@example.com")) {
+		t.Error("EncryptResume() left the resume readable")
+	}
+
+	block, _ := pem.Decode(encrypted)
+	block.Bytes[len(block.Bytes)-1] ^= 1
+	tampered := pem.EncodeToMemory(block)
+
+	tests := []struct {
+		name       string
+		body       []byte
+		passphrase []byte
+		want       []byte
+		wantErr    error
+	}{
+		{"round trip", encrypted, passphrase, body, nil},
+		{"wrong passphrase", encrypted, []byte("incorrect horse"), nil, ErrPassphrase},
+		{"missing passphrase", encrypted, nil, nil, ErrPassphrase},
+		{"tampered ciphertext", tampered, passphrase, nil, ErrPassphrase},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := DecryptResume(test.body, test.passphrase)
+
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("DecryptResume() error = %v, want %v", err, test.wantErr)
+			}
+
+			if !bytes.Equal(got, test.want) {
+				t.Errorf("DecryptResume() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoadResumesEncrypted(t *testing.T) {
+	passphrase := []byte("correct horse battery staple")
+
+	encrypted, err := EncryptResume([]byte("contact:\n  email_address: private@example.com\n"), passphrase)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := writeResumeFiles(t, map[string]string{
+		"base.yaml":       mergeBase,
+		"secret.yaml.enc": string(encrypted),
+	})
+
+	base, secret := filepath.Join(dir, "base.yaml"), filepath.Join(dir, "secret.yaml.enc")
+
+	c, err := LoadResumeWithPassphrase(base, secret, passphrase)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Contact.EmailAddress != "private@example.com" {
+		t.Errorf("LoadResumeWithPassphrase() email address = %q, want the secret one", c.Contact.EmailAddress)
+	}
+
+	if _, err = LoadResume(base, secret); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("LoadResume() of an encrypted file without a passphrase error = %v, want %v", err, ErrPassphrase)
+	}
+}
@@ -0,0 +1,153 @@
+package resume
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadControlsNode reads the controls file, and every controls file it extends,
+// into a single mapping where each file overrides the keys it sets; it's nil
+// for an empty file
+//
+// Files are extended relative to the file extending them, and font files are
+// resolved relative to the file declaring them; extending lists the files
+// already being loaded, each extending the next
+func loadControlsNode(controlsFile string, extending []string) (*yaml.Node, error) {
+	path, err := filepath.Abs(controlsFile)
+
+	if err != nil {
+		return nil, fmt.Errorf("error resolving controls file %s: %w", controlsFile, err)
+	}
+
+	for _, extender := range extending {
+		if extenderPath, _ := filepath.Abs(extender); extenderPath == path {
+			return nil, fmt.Errorf("error extending controls file %s, which extends itself: %s", controlsFile, strings.Join(append(extending, controlsFile), " extends "))
+		}
+	}
+
+	controlsFileBody, err := os.ReadFile(controlsFile)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading controls file: %w", err)
+	}
+
+	var document yaml.Node
+
+	if err = yaml.Unmarshal(controlsFileBody, &document); err != nil {
+		return nil, fmt.Errorf("error decoding controls YAML %s: %w", controlsFile, err)
+	}
+
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	node := document.Content[0]
+
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("error decoding controls YAML %s: not a mapping", controlsFile)
+	}
+
+	extends, err := takeExtends(node)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding extends of controls file %s: %w", controlsFile, err)
+	}
+
+	resolveFontFiles(node, filepath.Dir(controlsFile))
+
+	if extends == "" {
+		return node, nil
+	}
+
+	if !filepath.IsAbs(extends) {
+		extends = filepath.Join(filepath.Dir(controlsFile), extends)
+	}
+
+	parent, err := loadControlsNode(extends, append(extending, controlsFile))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if parent == nil {
+		return node, nil
+	}
+
+	overlayControls(parent, node)
+
+	return parent, nil
+}
+
+// takeExtends removes the extends key from the controls, which isn't a control
+// itself
+func takeExtends(mapping *yaml.Node) (string, error) {
+	for i := 0; (i + 1) < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != "extends" {
+			continue
+		}
+
+		var extends string
+
+		if err := mapping.Content[i+1].Decode(&extends); err != nil {
+			return "", err
+		}
+
+		mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+
+		return extends, nil
+	}
+
+	return "", nil
+}
+
+// resolveFontFiles resolves the font files of pdf.fonts.families against the
+// directory of the controls file declaring them, once and for all, since the
+// controls extending it may live in another directory
+func resolveFontFiles(mapping *yaml.Node, dir string) {
+	families := mapping
+
+	for _, key := range []string{"pdf", "fonts", "families"} {
+		if families = mappingValue(families, key); (families == nil) || (families.Kind != yaml.MappingNode) {
+			return
+		}
+	}
+
+	for i := 1; i < len(families.Content); i += 2 {
+		files := families.Content[i]
+
+		if files.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for j := 1; j < len(files.Content); j += 2 {
+			file := files.Content[j]
+
+			if (file.Kind == yaml.ScalarNode) && (file.Value != "") && !filepath.IsAbs(file.Value) {
+				file.Value = filepath.Join(dir, file.Value)
+			}
+		}
+	}
+}
+
+// overlayControls overrides the parent controls with the keys the child sets:
+// mappings are merged key by key, and anything else, lists included, is
+// replaced
+func overlayControls(parent, child *yaml.Node) {
+	if (parent.Kind != yaml.MappingNode) || (child.Kind != yaml.MappingNode) {
+		*parent = *child
+
+		return
+	}
+
+	for i := 0; (i + 1) < len(child.Content); i += 2 {
+		if value := mappingValue(parent, child.Content[i].Value); value != nil {
+			overlayControls(value, child.Content[i+1])
+		} else {
+			parent.Content = append(parent.Content, child.Content[i], child.Content[i+1])
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package resume
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const extendsDefault = `
+pdf:
+  filename: Default.pdf
+text:
+  width: 72
+flavor:
+  header: Default Header
+  footer: Default Footer
+layout:
+  sections:
+    - employers.expanded
+    - education
+employers:
+  expanded:
+    collapse_multiple_positions: full
+    count: 3
+    tags:
+      - mainline
+      - extra
+`
+
+func TestLoadControlsExtends(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		load  string
+		check func(t *testing.T, dir string, cc *ConfigurationControls)
+	}{
+		{
+			name: "every level overrides the one it extends",
+			files: map[string]string{
+				"default.yaml": extendsDefault,
+				"middle.yaml":  "extends: default.yaml\nflavor:\n  header: Middle Header\ntext:\n  width: 60\n",
+				"top.yaml":     "extends: middle.yaml\ntext:\n  width: 100\n",
+			},
+			load: "top.yaml",
+			check: func(t *testing.T, dir string, cc *ConfigurationControls) {
+				got := []interface{}{cc.Pdf.Filename, cc.Flavor.Header, cc.Flavor.Footer, cc.Text.Width, cc.Employers.Expanded.Count}
+				want := []interface{}{"Default.pdf", "Middle Header", "Default Footer", 100, uint(3)}
+
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("LoadControls() = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name: "lists are replaced rather than merged",
+			files: map[string]string{
+				"default.yaml": extendsDefault,
+				"top.yaml":     "extends: default.yaml\nemployers:\n  expanded:\n    tags:\n      - community\n",
+			},
+			load: "top.yaml",
+			check: func(t *testing.T, dir string, cc *ConfigurationControls) {
+				if want := []string{"community"}; !reflect.DeepEqual(cc.Employers.Expanded.Tags, want) {
+					t.Errorf("LoadControls() employers.expanded.tags = %q, want %q", cc.Employers.Expanded.Tags, want)
+				}
+
+				if want := []string{"employers.expanded", "education"}; !reflect.DeepEqual(cc.Layout.Sections, want) {
+					t.Errorf("LoadControls() layout.sections = %q, want the inherited %q", cc.Layout.Sections, want)
+				}
+			},
+		},
+		{
+			name: "an empty file extended changes nothing",
+			files: map[string]string{
+				"empty.yaml": "",
+				"top.yaml":   "extends: empty.yaml\n" + extendsDefault,
+			},
+			load: "top.yaml",
+			check: func(t *testing.T, dir string, cc *ConfigurationControls) {
+				if cc.Pdf.Filename != "Default.pdf" {
+					t.Errorf("LoadControls() pdf.filename = %q, want the file's own", cc.Pdf.Filename)
+				}
+			},
+		},
+		{
+			name: "font files are relative to the file declaring them",
+			files: map[string]string{
+				"shared/default.yaml":                      "employers:\n  expanded:\n    collapse_multiple_positions: full\npdf:\n  fonts:\n    families:\n      Lato:\n        regular: fonts/Lato-Regular.ttf\n        bold: ../bold/Lato-Bold.ttf\n",
+				"shared/fonts/Lato-Regular.ttf":            "",
+				"bold/Lato-Bold.ttf":                       "",
+				"volunteer/top.yaml":                       "extends: ../shared/default.yaml\npdf:\n  fonts:\n    families:\n      Merriweather:\n        regular: fonts/Merriweather-Regular.ttf\n",
+				"volunteer/fonts/Merriweather-Regular.ttf": "",
+			},
+			load: "volunteer/top.yaml",
+			check: func(t *testing.T, dir string, cc *ConfigurationControls) {
+				want := map[string]ConfigurationControlsPdfFontFamily{
+					"Lato": {
+						Regular: filepath.Join(dir, "shared", "fonts", "Lato-Regular.ttf"),
+						Bold:    filepath.Join(dir, "bold", "Lato-Bold.ttf"),
+					},
+					"Merriweather": {
+						Regular: filepath.Join(dir, "volunteer", "fonts", "Merriweather-Regular.ttf"),
+					},
+				}
+
+				if !reflect.DeepEqual(cc.Pdf.Fonts.Families, want) {
+					t.Errorf("LoadControls() pdf.fonts.families = %+v, want %+v", cc.Pdf.Fonts.Families, want)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := writeResumeFiles(t, test.files)
+
+			cc, err := LoadControls(filepath.Join(dir, test.load))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			test.check(t, dir, cc)
+		})
+	}
+}
+
+func TestLoadControlsExtendsCycle(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		chain []string
+	}{
+		{
+			name:  "itself",
+			files: map[string]string{"a.yaml": "extends: a.yaml\n"},
+			chain: []string{"a.yaml", "a.yaml"},
+		},
+		{
+			name: "through other files",
+			files: map[string]string{
+				"a.yaml":     "extends: sub/b.yaml\n",
+				"sub/b.yaml": "extends: ../c.yaml\n",
+				"c.yaml":     "extends: sub/b.yaml\n",
+			},
+			chain: []string{"a.yaml", "sub/b.yaml", "c.yaml", "sub/b.yaml"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := writeResumeFiles(t, test.files)
+
+			_, err := LoadControls(filepath.Join(dir, "a.yaml"))
+
+			if err == nil {
+				t.Fatal("LoadControls() of an extends cycle didn't fail")
+			}
+
+			chain := make([]string, len(test.chain))
+
+			for i, name := range test.chain {
+				chain[i] = filepath.Join(dir, name)
+			}
+
+			if want := strings.Join(chain, " extends "); !strings.HasSuffix(err.Error(), want) {
+				t.Errorf("LoadControls() error = %v, want the chain %s", err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package resume
+
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+// Options tunes a single generation of a resume document
+type Options struct {
+	// Timestamp is used for the document dates and footer; defaults to now
+	Timestamp time.Time
+
+	// Format selects the kind of document to output; defaults to PDF
+	Format string
+
+	// Renderer overrides the renderer selected by the controls
+	Renderer string
+
+	// Full renders every entry of the resume instead of the controls' selection
+	Full bool
+
+	// Public leaves the email address and phone number out of the document,
+	// showing the controls' placeholder instead
+	Public bool
+
+	// Warn receives problems which don't stop the document from rendering,
+	// such as characters a font can't draw; by default they're ignored
+	Warn func(message string)
+}
+
+func (o Options) timestamp() time.Time {
+	if o.Timestamp.IsZero() {
+		return time.Now()
+	}
+
+	return o.Timestamp
+}
+
+func (o Options) warn(message string) {
+	if o.Warn != nil {
+		o.Warn(message)
+	}
+}
+
+// Generate renders the resume described by the configuration and writes the
+// document to w
+func Generate(c *Configuration, w io.Writer, o Options) error {
+	r, err := ResolveRenderer(c, o)
+
+	if err != nil {
+		return err
+	}
+
+	p := Select(c)
+
+	if o.Full {
+		p = SelectAll(c)
+	}
+
+	if o.Public {
+		p.withholdContact()
+	}
+
+	return r.Render(p, w, o)
+}
+
+// ResolveRenderer finds the renderer the options and controls select
+func ResolveRenderer(c *Configuration, o Options) (Renderer, error) {
+	format := o.Format
+
+	if format == "" {
+		format = DefaultFormat
+	}
+
+	name := o.Renderer
+
+	if name == "" {
+		name = c.Controls.Renderer
+	}
+
+	if name == "" {
+		name = DefaultRenderer
+	}
+
+	return LookupRenderer(format, name)
+}
+
+// Filename is the name of the file the controls want the document written to,
+// using the extension of the renderer instead of the configured PDF one
+func Filename(c *Configuration, r Renderer) string {
+	return strings.TrimSuffix(c.Controls.Pdf.Filename, ".pdf") + "." + r.Extension()
+}
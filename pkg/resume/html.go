@@ -0,0 +1,327 @@
+package resume
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterRenderer("html", DefaultRenderer, classicHtmlRenderer{})
+}
+
+// classicHtmlRenderer produces a single self-contained page in the same style
+// as the classic PDF, which also prints cleanly
+type classicHtmlRenderer struct{}
+
+func (classicHtmlRenderer) Render(p *Plan, w io.Writer, o Options) error {
+	return classicHtmlTemplate.Execute(w, struct {
+		*Plan
+		Timestamp time.Time
+	}{p, o.timestamp()})
+}
+
+func (classicHtmlRenderer) Extension() string {
+	return "html"
+}
+
+var classicHtmlTemplate = template.Must(template.New("classic").Funcs(template.FuncMap{
+	"entryDates": entryDates,
+	"join":       strings.Join,
+	"pageSize": func(pg ConfigurationControlsPdfPage) template.CSS {
+		width, height := pg.dimensions()
+
+		return template.CSS(fmt.Sprintf("%gmm %gmm", width, height))
+	},
+	"phoneUrl": func(phoneNumber string) template.URL { return template.URL(phoneNumberUrl(phoneNumber)) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="author" content="{{.Contact.Name}}">
+<meta name="keywords" content="{{join .Controls.Pdf.Keywords ", "}}">
+<meta name="generator" content="{{.Contact.Repository}}">
+<title>{{.Contact.Name}}'s Resume</title>
+<style>
+:root {
+	--section-fill: #c8c8c8;
+	--muted: #555;
+}
+
+* {
+	box-sizing: border-box;
+}
+
+body {
+	margin: 0 auto;
+	max-width: 8.5in;
+	padding: 0.3in 0.4in;
+	font-family: Arial, Helvetica, sans-serif;
+	font-size: 11pt;
+	line-height: 1.35;
+	color: #000;
+	background: #fff;
+}
+
+a {
+	color: inherit;
+}
+
+header {
+	display: flex;
+	justify-content: space-between;
+	align-items: baseline;
+	font-family: "Times New Roman", Times, serif;
+	font-style: italic;
+	font-weight: bold;
+}
+
+header h1 {
+	margin: 0;
+	font-size: 18pt;
+}
+
+header p {
+	margin: 0;
+	font-size: 14pt;
+}
+
+.contact {
+	display: flex;
+	flex-wrap: wrap;
+	justify-content: space-between;
+	gap: 0 1em;
+	margin: 0.5em 0 0;
+	padding: 0;
+	list-style: none;
+	font-size: 9pt;
+}
+
+section h2 {
+	margin: 1.2em 0 0.5em;
+	padding: 0.15em 0.3em;
+	background: var(--section-fill);
+	font-size: 14pt;
+}
+
+.skills {
+	margin: 0;
+	text-align: center;
+}
+
+.line {
+	display: flex;
+	justify-content: space-between;
+	align-items: baseline;
+	gap: 1em;
+}
+
+.entry {
+	margin-bottom: 0.8em;
+	break-inside: avoid;
+}
+
+.organization {
+	font-style: italic;
+}
+
+.location,
+.institution,
+.authority,
+.subtitle {
+	font-size: 10pt;
+	font-style: italic;
+}
+
+.position {
+	font-size: 12pt;
+	font-weight: bold;
+}
+
+.flavor {
+	font-weight: normal;
+}
+
+.summary {
+	margin: 0.3em 0;
+}
+
+ul.bullet-points {
+	margin: 0.2em 0 0;
+	padding-left: 1.5em;
+}
+
+.dates {
+	white-space: nowrap;
+}
+
+footer {
+	display: flex;
+	justify-content: space-between;
+	margin-top: 2em;
+	color: var(--muted);
+	font-family: "Times New Roman", Times, serif;
+	font-size: 8pt;
+	font-style: italic;
+}
+
+@media print {
+	@page {
+		size: {{pageSize .Controls.Pdf.Page}};
+		margin: 0.35in 0.4in;
+	}
+
+	body {
+		max-width: none;
+		padding: 0;
+	}
+
+	a {
+		text-decoration: none;
+	}
+
+	section h2 {
+		break-after: avoid;
+		-webkit-print-color-adjust: exact;
+		print-color-adjust: exact;
+	}
+}
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Contact.Name}}</h1>
+<p>{{.Controls.Flavor.Header}}</p>
+</header>
+<ul class="contact">
+{{- with .Contact.Pronouns}}
+<li>{{.}}</li>
+{{- end}}
+{{- with .Contact.EmailAddress}}
+<li><a href="mailto:{{.}}">{{.}}</a></li>
+{{- end}}
+{{- with .Withheld}}
+<li>{{.}}</li>
+{{- end}}
+{{- with .Contact.PhoneNumber}}
+<li><a href="{{phoneUrl .}}">{{.}}</a></li>
+{{- end}}
+{{- with .Contact.Url}}
+<li><a href="{{.}}">{{.}}</a></li>
+{{- end}}
+{{- with .Contact.Location}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+<main>
+{{- range .Sections}}
+<section id="{{.Name}}">
+<h2>{{.Title}}</h2>
+{{- if eq .Kind "skills"}}
+<p class="skills">{{join .Skills " / "}}</p>
+{{- else if eq .Kind "organizations"}}
+{{- $section := .}}
+{{- range .Organizations}}
+<article class="entry">
+<div class="line">
+<span class="organization">{{if .Url}}<a href="{{.Url}}">{{.Organization}}</a>{{else}}{{.Organization}}{{end}}{{with .OrganizationExtra}} ({{.}}){{end}}</span>
+<span class="location">{{.Location}}</span>
+</div>
+{{- if $section.TitlesOnly}}
+{{- range .Positions}}
+{{template "position" .}}
+{{- end}}
+{{- range .Positions}}
+{{- template "details" .}}
+{{- end}}
+{{- else}}
+{{- range .Positions}}
+{{template "position" .}}
+{{- template "details" .}}
+{{- end}}
+{{- end}}
+</article>
+{{- end}}
+{{- else if eq .Kind "education"}}
+{{- range .Education}}
+<div class="line entry">
+<strong>{{if .Url}}<a href="{{.Url}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</strong>
+<span class="institution">{{.Institution}}</span>
+</div>
+{{- end}}
+{{- else if eq .Kind "projects"}}
+{{- range .Projects}}
+<article class="entry">
+<div class="line">
+<span class="organization">{{if .Url}}<a href="{{.Url}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</span>
+<span class="location">{{.Location}}</span>
+</div>
+<div class="line position">
+<span>{{.Role}}</span>
+<span class="dates">{{.Dates.Start}} to {{.Dates.End}}</span>
+</div>
+{{- with .Summary}}
+<p class="summary">{{.}}</p>
+{{- end}}
+{{- with .BulletPoints}}
+<ul class="bullet-points">
+{{- range .}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</article>
+{{- end}}
+{{- else if eq .Kind "certifications"}}
+{{- range .Certifications}}
+<div class="line entry">
+<span><strong>{{if .Url}}<a href="{{.Url}}">{{.Certification}}</a>{{else}}{{.Certification}}{{end}}</strong> ({{.Dates.Start}}-{{.Dates.End}})</span>
+<span class="authority">{{.Authority}}</span>
+</div>
+{{- end}}
+{{- else if eq .Kind "custom"}}
+{{- range .Entries}}
+<article class="entry">
+<div class="line">
+<span><strong>{{if .Url}}<a href="{{.Url}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</strong>{{with entryDates .Dates}} ({{.}}){{end}}</span>
+<span class="subtitle">{{.Subtitle}}{{if and .Subtitle .Location}}, {{end}}{{.Location}}</span>
+</div>
+{{- with .BulletPoints}}
+<ul class="bullet-points">
+{{- range .}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</article>
+{{- end}}
+{{- end}}
+</section>
+{{- end}}
+</main>
+<footer>
+<span>{{with .Contact.Repository}}<a href="{{.}}">{{.}}</a>{{end}}</span>
+<span>{{.Controls.Flavor.Footer}}_{{.Timestamp.Format "2006-01-02-15-04-05-0700"}}</span>
+</footer>
+</body>
+</html>
+{{- define "position"}}<div class="line position">
+<span>{{.Title}}{{with .Flavor}}<span class="flavor"> - {{.}}</span>{{end}}</span>
+<span class="dates">{{.Dates.Start}} to {{.Dates.End}}</span>
+</div>{{end}}
+{{- define "details"}}
+{{- with .Summary}}
+<p class="summary">{{.}}</p>
+{{- end}}
+{{- with .BulletPoints}}
+<ul class="bullet-points">
+{{- range .}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+`))
@@ -0,0 +1,568 @@
+package resume
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// jsonResumeSchema is the JSON Resume schema exported documents declare
+const jsonResumeSchema = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// jsonResumeImportTag is given to every imported organization and
+// certification, since the default controls select them by it
+const jsonResumeImportTag = "mainline"
+
+func init() {
+	// JSON Resume has no design of its own, so it's only registered under the
+	// default name for controls naming a renderer to still work
+	RegisterRenderer("jsonresume", DefaultRenderer, jsonResumeRenderer{})
+}
+
+// jsonResume is the subset of the JSON Resume schema (https://jsonresume.org/)
+// that the configuration has anything to say about
+type jsonResume struct {
+	Schema       string                  `json:"$schema,omitempty"`
+	Basics       jsonResumeBasics        `json:"basics"`
+	Work         []jsonResumeWork        `json:"work,omitempty"`
+	Volunteer    []jsonResumeVolunteer   `json:"volunteer,omitempty"`
+	Education    []jsonResumeEducation   `json:"education,omitempty"`
+	Certificates []jsonResumeCertificate `json:"certificates,omitempty"`
+	Skills       []jsonResumeSkill       `json:"skills,omitempty"`
+	Projects     []jsonResumeProject     `json:"projects,omitempty"`
+	Awards       []jsonResumeAward       `json:"awards,omitempty"`
+	Publications []jsonResumePublication `json:"publications,omitempty"`
+	Meta         *jsonResumeMeta         `json:"meta,omitempty"`
+}
+
+type jsonResumeBasics struct {
+	Name     string              `json:"name,omitempty"`
+	Label    string              `json:"label,omitempty"`
+	Email    string              `json:"email,omitempty"`
+	Phone    string              `json:"phone,omitempty"`
+	Url      string              `json:"url,omitempty"`
+	Summary  string              `json:"summary,omitempty"`
+	Location *jsonResumeLocation `json:"location,omitempty"`
+	Profiles []jsonResumeProfile `json:"profiles,omitempty"`
+}
+
+type jsonResumeLocation struct {
+	Address     string `json:"address,omitempty"`
+	City        string `json:"city,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+type jsonResumeProfile struct {
+	Network  string `json:"network,omitempty"`
+	Username string `json:"username,omitempty"`
+	Url      string `json:"url,omitempty"`
+}
+
+type jsonResumeWork struct {
+	Name        string   `json:"name,omitempty"`
+	Location    string   `json:"location,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Position    string   `json:"position,omitempty"`
+	Url         string   `json:"url,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Summary     string   `json:"summary,omitempty"`
+	Highlights  []string `json:"highlights,omitempty"`
+}
+
+type jsonResumeVolunteer struct {
+	Organization string   `json:"organization,omitempty"`
+	Position     string   `json:"position,omitempty"`
+	Url          string   `json:"url,omitempty"`
+	StartDate    string   `json:"startDate,omitempty"`
+	EndDate      string   `json:"endDate,omitempty"`
+	Summary      string   `json:"summary,omitempty"`
+	Highlights   []string `json:"highlights,omitempty"`
+}
+
+type jsonResumeEducation struct {
+	Institution string `json:"institution,omitempty"`
+	Url         string `json:"url,omitempty"`
+	Area        string `json:"area,omitempty"`
+	StudyType   string `json:"studyType,omitempty"`
+	StartDate   string `json:"startDate,omitempty"`
+	EndDate     string `json:"endDate,omitempty"`
+}
+
+type jsonResumeCertificate struct {
+	Name   string `json:"name,omitempty"`
+	Date   string `json:"date,omitempty"`
+	Url    string `json:"url,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+}
+
+type jsonResumeSkill struct {
+	Name     string   `json:"name,omitempty"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+type jsonResumeProject struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Highlights  []string `json:"highlights,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Url         string   `json:"url,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Entity      string   `json:"entity,omitempty"`
+	Type        string   `json:"type,omitempty"`
+}
+
+type jsonResumeAward struct {
+	Title   string `json:"title,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Awarder string `json:"awarder,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+type jsonResumePublication struct {
+	Name        string `json:"name,omitempty"`
+	Publisher   string `json:"publisher,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	Url         string `json:"url,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+}
+
+type jsonResumeMeta struct {
+	Canonical    string `json:"canonical,omitempty"`
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// jsonResumeRenderer exports the plan as a JSON Resume document; with the full
+// option it's the whole resume, otherwise only what the controls select
+type jsonResumeRenderer struct{}
+
+func (jsonResumeRenderer) Render(p *Plan, w io.Writer, o Options) error {
+	jr := jsonResume{
+		Schema: jsonResumeSchema,
+		Basics: jsonResumeBasics{
+			Name:     p.Contact.Name,
+			Label:    p.Controls.Flavor.Header,
+			Email:    p.Contact.EmailAddress,
+			Phone:    p.Contact.PhoneNumber,
+			Url:      p.Contact.Url,
+			Location: jsonResumeLocationOf(p.Contact.Location),
+		},
+		Meta: &jsonResumeMeta{
+			LastModified: o.timestamp().UTC().Format("2006-01-02T15:04:05"),
+		},
+	}
+
+	for _, s := range p.Sections {
+		switch s.Kind {
+		case SectionSkills:
+			if len(s.Skills) > 0 {
+				jr.Skills = append(jr.Skills, jsonResumeSkill{
+					Name:     s.Title,
+					Keywords: s.Skills,
+				})
+			}
+		case SectionOrganizations:
+			// Politics has no counterpart in JSON Resume, so it's volunteering
+			volunteer := !strings.HasPrefix(s.Name, "employers")
+
+			for _, organization := range s.Organizations {
+				for _, position := range organization.Positions {
+					title := position.Title
+
+					if position.Flavor != "" {
+						title += " - " + position.Flavor
+					}
+
+					if volunteer {
+						jr.Volunteer = append(jr.Volunteer, jsonResumeVolunteer{
+							Organization: organization.Organization,
+							Position:     title,
+							Url:          organization.Url,
+							StartDate:    jsonResumeDate(position.Dates.Start),
+							EndDate:      jsonResumeDate(position.Dates.End),
+							Summary:      position.Summary,
+							Highlights:   position.BulletPoints,
+						})
+					} else {
+						jr.Work = append(jr.Work, jsonResumeWork{
+							Name:        organization.Organization,
+							Location:    organization.Location,
+							Description: organization.OrganizationExtra,
+							Position:    title,
+							Url:         organization.Url,
+							StartDate:   jsonResumeDate(position.Dates.Start),
+							EndDate:     jsonResumeDate(position.Dates.End),
+							Summary:     position.Summary,
+							Highlights:  position.BulletPoints,
+						})
+					}
+				}
+			}
+		case SectionEducation:
+			for _, education := range s.Education {
+				jr.Education = append(jr.Education, jsonResumeEducation{
+					Institution: education.Institution,
+					Url:         education.Url,
+					Area:        education.Title,
+				})
+			}
+		case SectionProjects:
+			for _, project := range s.Projects {
+				jp := jsonResumeProject{
+					Name:        project.Title,
+					Description: project.Summary,
+					Highlights:  project.BulletPoints,
+					StartDate:   jsonResumeDate(project.Dates.Start),
+					EndDate:     jsonResumeDate(project.Dates.End),
+					Url:         project.Url,
+				}
+
+				if project.Role != "" {
+					jp.Roles = []string{project.Role}
+				}
+
+				jr.Projects = append(jr.Projects, jp)
+			}
+		case SectionCertifications:
+			for _, certification := range s.Certifications {
+				jr.Certificates = append(jr.Certificates, jsonResumeCertificate{
+					Name:   certification.Certification,
+					Date:   jsonResumeDate(certification.Dates.Start),
+					Url:    certification.Url,
+					Issuer: certification.Authority,
+				})
+			}
+		case SectionCustom:
+			// Only awards and publications have counterparts in JSON Resume
+			switch strings.TrimPrefix(s.Name, "sections.") {
+			case "awards":
+				for _, entry := range s.Entries {
+					jr.Awards = append(jr.Awards, jsonResumeAward{
+						Title:   entry.Title,
+						Date:    jsonResumeDate(entry.Dates.Start),
+						Awarder: entry.Subtitle,
+						Summary: strings.Join(entry.BulletPoints, " "),
+					})
+				}
+			case "publications":
+				for _, entry := range s.Entries {
+					jr.Publications = append(jr.Publications, jsonResumePublication{
+						Name:        entry.Title,
+						Publisher:   entry.Subtitle,
+						ReleaseDate: jsonResumeDate(entry.Dates.Start),
+						Url:         entry.Url,
+						Summary:     strings.Join(entry.BulletPoints, " "),
+					})
+				}
+			default:
+				o.warn(fmt.Sprintf("section %s has no counterpart in JSON Resume, so it's left out", s.Name))
+			}
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(jr)
+}
+
+func (jsonResumeRenderer) Extension() string {
+	return "json"
+}
+
+// jsonResumeLocationOf splits a "City, Region, Country" location into its parts,
+// keeping anything after the city as the region
+func jsonResumeLocationOf(location string) *jsonResumeLocation {
+	if location == "" {
+		return nil
+	}
+
+	parts := strings.SplitN(location, ",", 2)
+
+	l := &jsonResumeLocation{
+		City: strings.TrimSpace(parts[0]),
+	}
+
+	if len(parts) > 1 {
+		l.Region = strings.TrimSpace(parts[1])
+	}
+
+	return l
+}
+
+// Date layouts the resume files use, most specific first
+var resumeDateLayouts = []string{
+	"2006-01-02",
+	"2006-01",
+	"Jan. 2006",
+	"Jan 2006",
+	"January 2006",
+	"2006",
+}
+
+// jsonResumeDate converts a resume date, such as "Apr. 2021", to the ISO 8601
+// form JSON Resume expects, such as "2021-04"; dates which can't be parsed,
+// including "Present", come back empty
+func jsonResumeDate(date string) string {
+	date = strings.TrimSpace(date)
+
+	for _, layout := range resumeDateLayouts {
+		t, err := time.Parse(layout, date)
+
+		if err != nil {
+			continue
+		}
+
+		switch layout {
+		case "2006-01-02":
+			return t.Format("2006-01-02")
+		case "2006":
+			return t.Format("2006")
+		default:
+			return t.Format("2006-01")
+		}
+	}
+
+	return ""
+}
+
+// ImportJsonResume converts a JSON Resume document into resume configurations;
+// the personal contact details, which are the email address and phone number,
+// go in the secret configuration and everything else in the base one
+func ImportJsonResume(r io.Reader) (base, secret *Configuration, err error) {
+	var jr jsonResume
+
+	if err = json.NewDecoder(r).Decode(&jr); err != nil {
+		return nil, nil, fmt.Errorf("error decoding JSON Resume: %w", err)
+	}
+
+	base = &Configuration{
+		Contact: ConfigurationContact{
+			Name: jr.Basics.Name,
+			Url:  jr.Basics.Url,
+		},
+	}
+
+	secret = &Configuration{
+		Contact: ConfigurationContact{
+			EmailAddress: jr.Basics.Email,
+			PhoneNumber:  jr.Basics.Phone,
+		},
+	}
+
+	if jr.Basics.Location != nil {
+		base.Contact.Location = jsonResumeLocationString(jr.Basics.Location)
+	}
+
+	// Each skill group becomes a tag on its keywords, so the controls can pick
+	// out whole groups
+	for _, skill := range jr.Skills {
+		if len(skill.Keywords) == 0 {
+			base.Skills = append(base.Skills, ConfigurationSkills{
+				Name: skill.Name,
+			})
+
+			continue
+		}
+
+		var tags []string
+
+		if tag := jsonResumeTag(skill.Name); tag != "" {
+			tags = []string{tag}
+		}
+
+		for _, keyword := range skill.Keywords {
+			base.Skills = append(base.Skills, ConfigurationSkills{
+				Name: keyword,
+				Tags: tags,
+			})
+		}
+	}
+
+	for _, work := range jr.Work {
+		base.Employment = appendJsonResumePosition(base.Employment, ConfigurationOrganization{
+			Organization:      work.Name,
+			OrganizationExtra: work.Description,
+			Url:               work.Url,
+			Location:          work.Location,
+			Tags:              []string{jsonResumeImportTag},
+		}, ConfigurationOrganizationPosition{
+			Title:        work.Position,
+			Summary:      work.Summary,
+			Dates:        resumeDates(work.StartDate, work.EndDate),
+			BulletPoints: work.Highlights,
+		})
+	}
+
+	for _, volunteer := range jr.Volunteer {
+		base.Volunteering = appendJsonResumePosition(base.Volunteering, ConfigurationOrganization{
+			Organization: volunteer.Organization,
+			Url:          volunteer.Url,
+			Tags:         []string{jsonResumeImportTag},
+		}, ConfigurationOrganizationPosition{
+			Title:        volunteer.Position,
+			Summary:      volunteer.Summary,
+			Dates:        resumeDates(volunteer.StartDate, volunteer.EndDate),
+			BulletPoints: volunteer.Highlights,
+		})
+	}
+
+	for _, education := range jr.Education {
+		title := education.Area
+
+		if (education.StudyType != "") && (education.Area != "") {
+			title = education.StudyType + " in " + education.Area
+		} else if education.StudyType != "" {
+			title = education.StudyType
+		}
+
+		base.Education = append(base.Education, ConfigurationEducation{
+			Title:       title,
+			Url:         education.Url,
+			Institution: education.Institution,
+		})
+	}
+
+	for _, project := range jr.Projects {
+		base.Projects = append(base.Projects, ConfigurationProject{
+			Title:        project.Name,
+			Url:          project.Url,
+			Role:         strings.Join(project.Roles, ", "),
+			Summary:      project.Description,
+			Dates:        resumeDates(project.StartDate, project.EndDate),
+			BulletPoints: project.Highlights,
+		})
+	}
+
+	for _, certificate := range jr.Certificates {
+		base.Certifications = append(base.Certifications, ConfigurationCertification{
+			Certification: certificate.Name,
+			Url:           certificate.Url,
+			Authority:     certificate.Issuer,
+			Dates: ConfigurationDates{
+				Start: resumeDate(certificate.Date),
+			},
+			Tags: []string{jsonResumeImportTag},
+		})
+	}
+
+	for _, award := range jr.Awards {
+		base.appendEntry("awards", ConfigurationEntry{
+			Title:        award.Title,
+			Subtitle:     award.Awarder,
+			Dates:        ConfigurationDates{Start: resumeDate(award.Date)},
+			BulletPoints: jsonResumeSummaryBulletPoints(award.Summary),
+		})
+	}
+
+	for _, publication := range jr.Publications {
+		base.appendEntry("publications", ConfigurationEntry{
+			Title:        publication.Name,
+			Subtitle:     publication.Publisher,
+			Url:          publication.Url,
+			Dates:        ConfigurationDates{Start: resumeDate(publication.ReleaseDate)},
+			BulletPoints: jsonResumeSummaryBulletPoints(publication.Summary),
+		})
+	}
+
+	return base, secret, nil
+}
+
+func (c *Configuration) appendEntry(section string, entry ConfigurationEntry) {
+	if c.Sections == nil {
+		c.Sections = make(map[string][]ConfigurationEntry)
+	}
+
+	c.Sections[section] = append(c.Sections[section], entry)
+}
+
+// jsonResumeSummaryBulletPoints makes a summary the single bullet point of a
+// custom section entry, which has no summary of its own
+func jsonResumeSummaryBulletPoints(summary string) []string {
+	if summary = strings.TrimSpace(summary); summary == "" {
+		return nil
+	}
+
+	return []string{summary}
+}
+
+// appendJsonResumePosition adds the position to the organization when it's the
+// same as the last one, since JSON Resume lists every position on its own
+func appendJsonResumePosition(co []ConfigurationOrganization, organization ConfigurationOrganization, position ConfigurationOrganizationPosition) []ConfigurationOrganization {
+	if last := len(co) - 1; (last >= 0) && (co[last].Organization == organization.Organization) {
+		co[last].Positions = append(co[last].Positions, position)
+
+		return co
+	}
+
+	organization.Positions = []ConfigurationOrganizationPosition{position}
+
+	return append(co, organization)
+}
+
+func jsonResumeLocationString(l *jsonResumeLocation) string {
+	var parts []string
+
+	for _, part := range []string{l.City, l.Region, l.CountryCode} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	// Only fall back on the street address, which is better kept private
+	if len(parts) == 0 {
+		return strings.TrimSpace(l.Address)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// jsonResumeTag turns a skill group name into a tag, such as "Web Development"
+// into "web_development"
+func jsonResumeTag(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "_")
+}
+
+func resumeDates(start, end string) ConfigurationDates {
+	dates := ConfigurationDates{
+		Start: resumeDate(start),
+		End:   resumeDate(end),
+	}
+
+	if dates.End == "" {
+		dates.End = "Present"
+	}
+
+	return dates
+}
+
+// resumeDate converts an ISO 8601 date from JSON Resume, such as "2021-04", to
+// the form the resume files use, such as "Apr. 2021"; anything else is kept as
+// it is
+func resumeDate(date string) string {
+	date = strings.TrimSpace(date)
+
+	for _, layout := range []string{"2006-01-02", "2006-01"} {
+		if t, err := time.Parse(layout, date); err == nil {
+			// May is short enough to never be abbreviated
+			if t.Month() == time.May {
+				return t.Format("January 2006")
+			}
+
+			return t.Format("Jan. 2006")
+		}
+	}
+
+	return date
+}
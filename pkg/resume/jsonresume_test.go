@@ -0,0 +1,421 @@
+package resume
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func jsonResumeTestResume() *Configuration {
+	return &Configuration{
+		Controls: ConfigurationControls{
+			Flavor: ConfigurationControlsFlavor{Header: "Engineering Leader"},
+		},
+		Contact: ConfigurationContact{
+			Name:         "Test Person",
+			EmailAddress: "private@example.com",
+			PhoneNumber:  "+1 555 555 0100",
+			Url:          "https://example.com/",
+			Location:     "Portland, OR, USA",
+		},
+		Skills: []ConfigurationSkills{
+			{Name: "Go"},
+			{Name: "Kubernetes"},
+		},
+		Employment: []ConfigurationOrganization{
+			{
+				Organization:      "Acme",
+				OrganizationExtra: "of Acme Holdings",
+				Url:               "https://acme.example.com/",
+				Location:          "Remote",
+				Positions: []ConfigurationOrganizationPosition{
+					{
+						Title:        "Manager",
+						Flavor:       "Platform",
+						Summary:      "Ran the team",
+						Dates:        ConfigurationDates{Start: "Jan. 2021", End: "Present"},
+						BulletPoints: []string{"a1", "a2"},
+					},
+					{
+						Title:           "Engineer II",
+						NormalizedTitle: "Engineer",
+						Dates:           ConfigurationDates{Start: "May 2018", End: "Jan. 2021"},
+					},
+				},
+			},
+		},
+		Politics: []ConfigurationOrganization{
+			{
+				Organization: "Campaign",
+				Positions: []ConfigurationOrganizationPosition{
+					{Title: "Volunteer", Dates: ConfigurationDates{Start: "2016", End: "2016"}},
+				},
+			},
+		},
+		Education: []ConfigurationEducation{
+			{Title: "B.S. Computer Science", Institution: "State University", Url: "https://state.example.edu/"},
+		},
+		Projects: []ConfigurationProject{
+			{
+				Title:        "Resume",
+				Url:          "https://github.com/example/resume",
+				Role:         "Author",
+				Summary:      "A resume generator",
+				Dates:        ConfigurationDates{Start: "2020-03-15", End: "Present"},
+				BulletPoints: []string{"p1"},
+			},
+		},
+		Certifications: []ConfigurationCertification{
+			{Certification: "Kubernetes Administrator", Authority: "CNCF", Dates: ConfigurationDates{Start: "Sep. 2019"}},
+		},
+		Sections: map[string][]ConfigurationEntry{
+			"awards": {
+				{Title: "Engineer of the Year", Subtitle: "Acme", Dates: ConfigurationDates{Start: "Dec. 2019"}, BulletPoints: []string{"For", "everything"}},
+			},
+			"talks": {
+				{Title: "Go at Scale"},
+			},
+		},
+	}
+}
+
+func TestJsonResumeExport(t *testing.T) {
+	timestamp := time.Date(2026, time.April, 1, 12, 30, 0, 0, time.UTC)
+
+	var (
+		b        bytes.Buffer
+		warnings []string
+	)
+
+	err := Generate(jsonResumeTestResume(), &b, Options{
+		Timestamp: timestamp,
+		Format:    "jsonresume",
+		Full:      true,
+		Warn:      func(message string) { warnings = append(warnings, message) },
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got jsonResume
+
+	if err = json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := jsonResume{
+		Schema: jsonResumeSchema,
+		Basics: jsonResumeBasics{
+			Name:     "Test Person",
+			Label:    "Engineering Leader",
+			Email:    "private@example.com",
+			Phone:    "+1 555 555 0100",
+			Url:      "https://example.com/",
+			Location: &jsonResumeLocation{City: "Portland", Region: "OR, USA"},
+		},
+		Work: []jsonResumeWork{
+			{
+				Name:        "Acme",
+				Location:    "Remote",
+				Description: "of Acme Holdings",
+				Position:    "Manager - Platform",
+				Url:         "https://acme.example.com/",
+				StartDate:   "2021-01",
+				Summary:     "Ran the team",
+				Highlights:  []string{"a1", "a2"},
+			},
+			{
+				Name:        "Acme",
+				Location:    "Remote",
+				Description: "of Acme Holdings",
+				Position:    "Engineer",
+				Url:         "https://acme.example.com/",
+				StartDate:   "2018-05",
+				EndDate:     "2021-01",
+			},
+		},
+		// Politics has no counterpart of its own
+		Volunteer: []jsonResumeVolunteer{
+			{Organization: "Campaign", Position: "Volunteer", StartDate: "2016", EndDate: "2016"},
+		},
+		Education: []jsonResumeEducation{
+			{Institution: "State University", Url: "https://state.example.edu/", Area: "B.S. Computer Science"},
+		},
+		Certificates: []jsonResumeCertificate{
+			{Name: "Kubernetes Administrator", Date: "2019-09", Issuer: "CNCF"},
+		},
+		Skills: []jsonResumeSkill{
+			{Name: "Skills", Keywords: []string{"Go", "Kubernetes"}},
+		},
+		Projects: []jsonResumeProject{
+			{
+				Name:        "Resume",
+				Description: "A resume generator",
+				Highlights:  []string{"p1"},
+				StartDate:   "2020-03-15",
+				Url:         "https://github.com/example/resume",
+				Roles:       []string{"Author"},
+			},
+		},
+		Awards: []jsonResumeAward{
+			{Title: "Engineer of the Year", Date: "2019-12", Awarder: "Acme", Summary: "For everything"},
+		},
+		Meta: &jsonResumeMeta{LastModified: "2026-04-01T12:30:00"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON Resume export =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if wantWarnings := []string{"section sections.talks has no counterpart in JSON Resume, so it's left out"}; !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("JSON Resume export warnings = %q, want %q", warnings, wantWarnings)
+	}
+}
+
+func TestJsonResumeDate(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2021-04-15", "2021-04-15"},
+		{"2021-04", "2021-04"},
+		{"Apr. 2021", "2021-04"},
+		{"Apr 2021", "2021-04"},
+		{"April 2021", "2021-04"},
+		{"May 2021", "2021-05"},
+		{" Sep. 2019 ", "2019-09"},
+		{"2021", "2021"},
+		{"Present", ""},
+		{"", ""},
+		{"Spring 2021", ""},
+	}
+
+	for _, test := range tests {
+		if got := jsonResumeDate(test.date); got != test.want {
+			t.Errorf("jsonResumeDate(%q) = %q, want %q", test.date, got, test.want)
+		}
+	}
+}
+
+func TestImportJsonResume(t *testing.T) {
+	jr := `{
+  "basics": {
+    "name": "Test Person",
+    "email": "private@example.com",
+    "phone": "+1 555 555 0100",
+    "url": "https://example.com/",
+    "location": {"address": "1 Main St.", "city": "Portland", "region": "OR", "countryCode": "US"}
+  },
+  "skills": [
+    {"name": "Web Development", "keywords": ["Go", "HTML"]},
+    {"name": "Kubernetes"}
+  ],
+  "work": [
+    {"name": "Acme", "location": "Remote", "position": "Manager", "startDate": "2021-01", "highlights": ["a1"]},
+    {"name": "Acme", "location": "Remote", "position": "Engineer", "startDate": "2018-05-14", "endDate": "2021-01"},
+    {"name": "Initech", "position": "Developer", "startDate": "2014-02", "endDate": "2018-03"},
+    {"name": "Acme", "position": "Intern", "startDate": "2012-06", "endDate": "2012-08"}
+  ],
+  "volunteer": [
+    {"organization": "Food Bank", "position": "Driver", "startDate": "2019", "endDate": "2020"}
+  ],
+  "education": [
+    {"institution": "State University", "studyType": "B.S.", "area": "Computer Science"},
+    {"institution": "Community College", "studyType": "A.S."},
+    {"institution": "Online", "area": "Statistics"}
+  ],
+  "projects": [
+    {"name": "Resume", "description": "A resume generator", "roles": ["Author", "Maintainer"], "startDate": "2020-03"}
+  ],
+  "certificates": [
+    {"name": "Kubernetes Administrator", "issuer": "CNCF", "date": "2019-09-01"}
+  ],
+  "awards": [
+    {"title": "Engineer of the Year", "awarder": "Acme", "date": "2019-12", "summary": " For everything "}
+  ],
+  "publications": [
+    {"name": "Go at Scale", "publisher": "Example Press", "releaseDate": "2022-10", "url": "https://example.com/go"}
+  ]
+}`
+
+	base, secret, err := ImportJsonResume(strings.NewReader(jr))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mainline := []string{"mainline"}
+
+	wantBase := &Configuration{
+		Contact: ConfigurationContact{
+			Name:     "Test Person",
+			Url:      "https://example.com/",
+			Location: "Portland, OR, US",
+		},
+		Skills: []ConfigurationSkills{
+			{Name: "Go", Tags: []string{"web_development"}},
+			{Name: "HTML", Tags: []string{"web_development"}},
+			{Name: "Kubernetes"},
+		},
+		// Only consecutive positions at the same organization are merged
+		Employment: []ConfigurationOrganization{
+			{
+				Organization: "Acme",
+				Location:     "Remote",
+				Tags:         mainline,
+				Positions: []ConfigurationOrganizationPosition{
+					{Title: "Manager", Dates: ConfigurationDates{Start: "Jan. 2021", End: "Present"}, BulletPoints: []string{"a1"}},
+					{Title: "Engineer", Dates: ConfigurationDates{Start: "May 2018", End: "Jan. 2021"}},
+				},
+			},
+			{
+				Organization: "Initech",
+				Tags:         mainline,
+				Positions: []ConfigurationOrganizationPosition{
+					{Title: "Developer", Dates: ConfigurationDates{Start: "Feb. 2014", End: "Mar. 2018"}},
+				},
+			},
+			{
+				Organization: "Acme",
+				Tags:         mainline,
+				Positions: []ConfigurationOrganizationPosition{
+					{Title: "Intern", Dates: ConfigurationDates{Start: "Jun. 2012", End: "Aug. 2012"}},
+				},
+			},
+		},
+		Volunteering: []ConfigurationOrganization{
+			{
+				Organization: "Food Bank",
+				Tags:         mainline,
+				Positions: []ConfigurationOrganizationPosition{
+					{Title: "Driver", Dates: ConfigurationDates{Start: "2019", End: "2020"}},
+				},
+			},
+		},
+		Education: []ConfigurationEducation{
+			{Title: "B.S. in Computer Science", Institution: "State University"},
+			{Title: "A.S.", Institution: "Community College"},
+			{Title: "Statistics", Institution: "Online"},
+		},
+		Projects: []ConfigurationProject{
+			{Title: "Resume", Role: "Author, Maintainer", Summary: "A resume generator", Dates: ConfigurationDates{Start: "Mar. 2020", End: "Present"}},
+		},
+		Certifications: []ConfigurationCertification{
+			{Certification: "Kubernetes Administrator", Authority: "CNCF", Dates: ConfigurationDates{Start: "Sep. 2019"}, Tags: mainline},
+		},
+		Sections: map[string][]ConfigurationEntry{
+			"awards": {
+				{Title: "Engineer of the Year", Subtitle: "Acme", Dates: ConfigurationDates{Start: "Dec. 2019"}, BulletPoints: []string{"For everything"}},
+			},
+			"publications": {
+				{Title: "Go at Scale", Subtitle: "Example Press", Url: "https://example.com/go", Dates: ConfigurationDates{Start: "Oct. 2022"}},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(base, wantBase) {
+		t.Errorf("ImportJsonResume() base =\n%+v\nwant\n%+v", base, wantBase)
+	}
+
+	wantSecret := &Configuration{
+		Contact: ConfigurationContact{
+			EmailAddress: "private@example.com",
+			PhoneNumber:  "+1 555 555 0100",
+		},
+	}
+
+	if !reflect.DeepEqual(secret, wantSecret) {
+		t.Errorf("ImportJsonResume() secret = %+v, want %+v", secret, wantSecret)
+	}
+}
+
+func TestImportJsonResumeInvalid(t *testing.T) {
+	if _, _, err := ImportJsonResume(strings.NewReader(`{"basics": []}`)); err == nil {
+		t.Error("ImportJsonResume() of invalid JSON Resume didn't fail")
+	}
+}
+
+func TestJsonResumeRoundTrip(t *testing.T) {
+	c := jsonResumeTestResume()
+
+	// Only what survives the trip: JSON Resume has no flavors, normalized
+	// titles, or politics, and the controls' header isn't imported
+	c.Controls = ConfigurationControls{}
+	c.Contact.Location = "Portland, OR"
+	c.Employment[0].Positions[0].Flavor = ""
+	c.Employment[0].Positions[1].Title = "Engineer"
+	c.Employment[0].Positions[1].NormalizedTitle = ""
+	c.Politics = nil
+	c.Sections = map[string][]ConfigurationEntry{
+		"awards": {
+			{Title: "Engineer of the Year", Subtitle: "Acme", Dates: ConfigurationDates{Start: "Dec. 2019"}, BulletPoints: []string{"For everything"}},
+		},
+	}
+
+	var b bytes.Buffer
+
+	if err := Generate(c, &b, Options{Format: "jsonresume", Full: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	base, secret, err := ImportJsonResume(&b)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every skill is tagged with the name of its group, and every organization
+	// and certification with the tag the default controls select
+	want := jsonResumeTestResume()
+	want.Controls = c.Controls
+	want.Contact = ConfigurationContact{Name: c.Contact.Name, Url: c.Contact.Url, Location: c.Contact.Location}
+	want.Skills = []ConfigurationSkills{
+		{Name: "Go", Tags: []string{"skills"}},
+		{Name: "Kubernetes", Tags: []string{"skills"}},
+	}
+	want.Employment = c.Employment
+	want.Employment[0].Tags = []string{"mainline"}
+	want.Politics = nil
+	want.Certifications[0].Tags = []string{"mainline"}
+	want.Projects[0].Dates.Start = "Mar. 2020"
+	want.Sections = c.Sections
+
+	if !reflect.DeepEqual(base, want) {
+		t.Errorf("ImportJsonResume() of an export =\n%+v\nwant\n%+v", base, want)
+	}
+
+	if (secret.Contact.EmailAddress != c.Contact.EmailAddress) || (secret.Contact.PhoneNumber != c.Contact.PhoneNumber) {
+		t.Errorf("ImportJsonResume() of an export secret contact = %+v, want the email address and phone number", secret.Contact)
+	}
+}
+
+func TestResumeDate(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2021-04-15", "Apr. 2021"},
+		{"2021-04", "Apr. 2021"},
+		{"2021-05", "May 2021"},
+		{" 2019-09 ", "Sep. 2019"},
+		{"2021", "2021"},
+		{"Present", "Present"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := resumeDate(test.date); got != test.want {
+			t.Errorf("resumeDate(%q) = %q, want %q", test.date, got, test.want)
+		}
+	}
+}
+
+func TestResumeDates(t *testing.T) {
+	if got, want := resumeDates("2021-04", ""), (ConfigurationDates{Start: "Apr. 2021", End: "Present"}); got != want {
+		t.Errorf("resumeDates() without an end = %+v, want %+v", got, want)
+	}
+}
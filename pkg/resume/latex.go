@@ -0,0 +1,258 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func init() {
+	RegisterRenderer("latex", DefaultRenderer, classicLatexRenderer{})
+}
+
+// classicLatexRenderer writes LaTeX source in the structure of the classic PDF,
+// for typesetting with pdflatex or any other LaTeX toolchain
+type classicLatexRenderer struct{}
+
+func (classicLatexRenderer) Render(p *Plan, w io.Writer, o Options) error {
+	var b bytes.Buffer
+
+	margins := p.Controls.Pdf.Margins
+	pageWidth, pageHeight := p.Controls.Pdf.Page.dimensions()
+
+	fmt.Fprintf(&b, latexPreamble,
+		pageWidth,
+		pageHeight,
+		margins.Left,
+		margins.Top,
+		margins.Right,
+		PageMarginBottom,
+		latexEscape(p.Contact.Name+"'s Resume"),
+		latexEscape(p.Contact.Name),
+		latexEscape(strings.Join(p.Controls.Pdf.Keywords, ", ")),
+		latexEscape(p.Controls.Flavor.Footer+"_"+o.timestamp().Format("2006-01-02-15-04-05-0700")),
+	)
+
+	if p.Contact.Repository != "" {
+		fmt.Fprintf(&b, "\\lfoot{\\footnotesize\\itshape %s}\n", latexLink(p.Contact.Repository, p.Contact.Repository))
+	}
+
+	b.WriteString("\n\\begin{document}\n\n")
+
+	fmt.Fprintf(&b, "\\resumeheader{%s}{%s}\n\n", latexEscape(p.Contact.Name), latexEscape(p.Controls.Flavor.Header))
+
+	contact := make([]string, 0, 5)
+
+	if p.Contact.Pronouns != "" {
+		contact = append(contact, latexEscape(p.Contact.Pronouns))
+	}
+
+	if p.Contact.EmailAddress != "" {
+		contact = append(contact, latexLink(p.Contact.EmailAddress, "mailto:"+p.Contact.EmailAddress))
+	}
+
+	if p.Withheld != "" {
+		contact = append(contact, latexEscape(p.Withheld))
+	}
+
+	if p.Contact.PhoneNumber != "" {
+		contact = append(contact, latexLink(p.Contact.PhoneNumber, phoneNumberUrl(p.Contact.PhoneNumber)))
+	}
+
+	if p.Contact.Url != "" {
+		contact = append(contact, latexLink(p.Contact.Url, p.Contact.Url))
+	}
+
+	if p.Contact.Location != "" {
+		contact = append(contact, latexEscape(p.Contact.Location))
+	}
+
+	if len(contact) > 0 {
+		fmt.Fprintf(&b, "{\\small %s}\n", strings.Join(contact, " \\hfill "))
+	}
+
+	for _, s := range p.Sections {
+		fmt.Fprintf(&b, "\n\\resumesection{%s}\n", latexEscape(s.Title))
+
+		switch s.Kind {
+		case SectionSkills:
+			skills := make([]string, len(s.Skills))
+
+			for i, skill := range s.Skills {
+				skills[i] = latexEscape(skill)
+			}
+
+			fmt.Fprintf(&b, "\\begin{center}\n%s\n\\end{center}\n", strings.Join(skills, " / "))
+		case SectionOrganizations:
+			for _, organization := range s.Organizations {
+				name := latexLink(organization.Organization, organization.Url)
+
+				if organization.OrganizationExtra != "" {
+					name += " (" + latexEscape(organization.OrganizationExtra) + ")"
+				}
+
+				fmt.Fprintf(&b, "\n\\resumeorganization{%s}{%s}\n", name, latexEscape(organization.Location))
+
+				s.EachPosition(&organization, func(position *PlanPosition) {
+					latexPositionTitle(&b, position)
+				}, func(position *PlanPosition) {
+					latexDetails(&b, position.Summary, position.BulletPoints)
+				})
+			}
+		case SectionEducation:
+			for _, education := range s.Education {
+				fmt.Fprintf(&b, "\\noindent\\textbf{%s} \\hfill {\\small\\itshape %s}\\par\n", latexLink(education.Title, education.Url), latexEscape(education.Institution))
+			}
+		case SectionProjects:
+			for _, project := range s.Projects {
+				fmt.Fprintf(&b, "\n\\resumeorganization{%s}{%s}\n", latexLink(project.Title, project.Url), latexEscape(project.Location))
+				fmt.Fprintf(&b, "\\resumeposition{%s}{%s}\n", latexEscape(project.Role), latexEscape(project.Dates.Start+" to "+project.Dates.End))
+
+				latexDetails(&b, project.Summary, project.BulletPoints)
+			}
+		case SectionCertifications:
+			for _, certification := range s.Certifications {
+				fmt.Fprintf(&b, "\\noindent\\textbf{%s} (%s) \\hfill {\\small\\itshape %s}\\par\n", latexLink(certification.Certification, certification.Url), latexEscape(certification.Dates.Start+"-"+certification.Dates.End), latexEscape(certification.Authority))
+			}
+		case SectionCustom:
+			for _, entry := range s.Entries {
+				title := "\\textbf{" + latexLink(entry.Title, entry.Url) + "}"
+
+				if dates := entryDates(entry.Dates); dates != "" {
+					title += " (" + latexEscape(dates) + ")"
+				}
+
+				details := make([]string, 0, 2)
+
+				for _, detail := range []string{entry.Subtitle, entry.Location} {
+					if detail != "" {
+						details = append(details, latexEscape(detail))
+					}
+				}
+
+				fmt.Fprintf(&b, "\\noindent %s \\hfill {\\small\\itshape %s}\\par\n", title, strings.Join(details, ", "))
+
+				latexDetails(&b, "", entry.BulletPoints)
+			}
+		}
+	}
+
+	b.WriteString("\n\\end{document}\n")
+
+	_, err := b.WriteTo(w)
+
+	return err
+}
+
+func (classicLatexRenderer) Extension() string {
+	return "tex"
+}
+
+func latexPositionTitle(b *bytes.Buffer, position *PlanPosition) {
+	title := latexEscape(position.Title)
+
+	if position.Flavor != "" {
+		title += " {\\normalfont - " + latexEscape(position.Flavor) + "}"
+	}
+
+	fmt.Fprintf(b, "\\resumeposition{%s}{%s}\n", title, latexEscape(position.Dates.Start+" to "+position.Dates.End))
+}
+
+func latexDetails(b *bytes.Buffer, summary string, bulletPoints []string) {
+	if summary != "" {
+		fmt.Fprintf(b, "%s\\par\n", latexEscape(summary))
+	}
+
+	if len(bulletPoints) > 0 {
+		b.WriteString("\\begin{itemize}\n")
+
+		// The braces stop a bullet point starting with [ from being read as the
+		// item's label
+		for _, bulletPoint := range bulletPoints {
+			fmt.Fprintf(b, "\\item{} %s\n", latexEscape(bulletPoint))
+		}
+
+		b.WriteString("\\end{itemize}\n")
+	}
+}
+
+// latexLink is a hyperref link, with the URL escaped apart from the text
+func latexLink(text, url string) string {
+	return linkedText(text, url, latexEscape, func(text, url string) string {
+		return fmt.Sprintf("\\href{%s}{%s}", latexUrlReplacer.Replace(url), text)
+	})
+}
+
+// Every character LaTeX treats specially, replaced in a single pass so that
+// the backslashes of the replacements are never escaped again
+var latexReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	"&", `\&`,
+	"%", `\%`,
+	"$", `\$`,
+	"#", `\#`,
+	"_", `\_`,
+	"{", `\{`,
+	"}", `\}`,
+	"~", `\textasciitilde{}`,
+	"^", `\textasciicircum{}`,
+)
+
+// Within \href only these need escaping
+var latexUrlReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"%", `\%`,
+	"#", `\#`,
+	"{", `\{`,
+	"}", `\}`,
+)
+
+func latexEscape(s string) string {
+	return latexReplacer.Replace(s)
+}
+
+const latexPreamble = `%% Generated by the resume generator; edit the YAML rather than this file
+\documentclass[11pt]{article}
+
+\usepackage[T1]{fontenc}
+\usepackage[utf8]{inputenc}
+\usepackage{lmodern}
+\usepackage[paperwidth=%gmm,paperheight=%gmm,left=%gmm,top=%gmm,right=%gmm,bottom=%gmm]{geometry}
+\usepackage[table]{xcolor}
+\usepackage{enumitem}
+\usepackage{fancyhdr}
+\usepackage[hidelinks]{hyperref}
+
+\hypersetup{
+  pdftitle={%s},
+  pdfauthor={%s},
+  pdfkeywords={%s}
+}
+
+\setlength{\parindent}{0pt}
+\setlist[itemize]{nosep,leftmargin=1.5em,label=\textbullet}
+
+\pagestyle{fancy}
+\fancyhf{}
+\renewcommand{\headrulewidth}{0pt}
+\rfoot{\footnotesize\itshape %s}
+
+%% \resumeheader{name}{flavor}
+\newcommand{\resumeheader}[2]{%%
+  {\Large\bfseries\itshape #1}\hfill{\large\bfseries\itshape #2}\par\medskip}
+
+%% \resumesection{title}
+\newcounter{resumesection}
+\newcommand{\resumesection}[1]{%%
+  \par\bigskip\stepcounter{resumesection}\pdfbookmark[0]{#1}{resumesection\theresumesection}%%
+  \noindent\colorbox[gray]{0.78}{\parbox{\dimexpr\linewidth-2\fboxsep}{\large\bfseries #1}}\par\medskip}
+
+%% \resumeorganization{organization}{location}
+\newcommand{\resumeorganization}[2]{%%
+  \par\smallskip\noindent{\itshape #1}\hfill{\small\itshape #2}\par}
+
+%% \resumeposition{title}{dates}
+\newcommand{\resumeposition}[2]{%%
+  \noindent{\large\bfseries #1}\hfill{\large\bfseries #2}\par}
+`
@@ -0,0 +1,67 @@
+package resume
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLatexEscape(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"R&D", `R\&D`},
+		{"100%", `100\%`},
+		{"$2M budget", `\$2M budget`},
+		{"~4 min", `\textasciitilde{}4 min`},
+		{"#1 and x_2", `\#1 and x\_2`},
+		{"{braces}", `\{braces\}`},
+		{"2^10", `2\textasciicircum{}10`},
+		{`C:\path`, `C:\textbackslash{}path`},
+		{"[bracketed] text", "[bracketed] text"},
+		{"plain text", "plain text"},
+	}
+
+	for _, test := range tests {
+		if got := latexEscape(test.s); got != test.want {
+			t.Errorf("latexEscape(%q) = %q, want %q", test.s, got, test.want)
+		}
+	}
+}
+
+func TestLatexLink(t *testing.T) {
+	tests := []struct {
+		text string
+		url  string
+		want string
+	}{
+		{"R&D", "", `R\&D`},
+		{"R&D", "https://example.com/a_b#c%20d", `\href{https://example.com/a_b\#c\%20d}{R\&D}`},
+	}
+
+	for _, test := range tests {
+		if got := latexLink(test.text, test.url); got != test.want {
+			t.Errorf("latexLink(%q, %q) = %q, want %q", test.text, test.url, got, test.want)
+		}
+	}
+}
+
+func TestLatexDetails(t *testing.T) {
+	var b bytes.Buffer
+
+	latexDetails(&b, "Ran 100% of it", []string{"[Internal] tooling & CI", "Saved $2M"})
+
+	want := strings.Join([]string{
+		`Ran 100\% of it\par`,
+		`\begin{itemize}`,
+		`\item{} [Internal] tooling \& CI`,
+		`\item{} Saved \$2M`,
+		`\end{itemize}`,
+		"",
+	}, "\n")
+
+	if got := b.String(); got != want {
+		t.Errorf("latexDetails() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,209 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func init() {
+	RegisterRenderer("markdown", DefaultRenderer, classicMarkdownRenderer{})
+}
+
+// classicMarkdownRenderer writes GitHub flavored Markdown, suitable for a
+// README or profile page
+type classicMarkdownRenderer struct{}
+
+func (classicMarkdownRenderer) Render(p *Plan, w io.Writer, o Options) error {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# %s\n\n", markdownEscape(p.Contact.Name))
+
+	if p.Controls.Flavor.Header != "" {
+		fmt.Fprintf(&b, "*%s*\n\n", markdownEscape(p.Controls.Flavor.Header))
+	}
+
+	contact := make([]string, 0, 5)
+
+	if p.Contact.Pronouns != "" {
+		contact = append(contact, markdownEscape(p.Contact.Pronouns))
+	}
+
+	if p.Contact.EmailAddress != "" {
+		contact = append(contact, markdownLink(p.Contact.EmailAddress, "mailto:"+p.Contact.EmailAddress))
+	}
+
+	if p.Withheld != "" {
+		contact = append(contact, markdownEscape(p.Withheld))
+	}
+
+	if p.Contact.PhoneNumber != "" {
+		contact = append(contact, markdownLink(p.Contact.PhoneNumber, phoneNumberUrl(p.Contact.PhoneNumber)))
+	}
+
+	if p.Contact.Url != "" {
+		contact = append(contact, markdownLink(p.Contact.Url, p.Contact.Url))
+	}
+
+	if p.Contact.Location != "" {
+		contact = append(contact, markdownEscape(p.Contact.Location))
+	}
+
+	if len(contact) > 0 {
+		fmt.Fprintf(&b, "%s\n", strings.Join(contact, " | "))
+	}
+
+	for _, s := range p.Sections {
+		fmt.Fprintf(&b, "\n## %s\n", markdownEscape(s.Title))
+
+		switch s.Kind {
+		case SectionSkills:
+			skills := make([]string, len(s.Skills))
+
+			for i, skill := range s.Skills {
+				skills[i] = markdownEscape(skill)
+			}
+
+			if len(skills) > 0 {
+				fmt.Fprintf(&b, "\n%s\n", strings.Join(skills, " / "))
+			}
+		case SectionOrganizations:
+			for _, organization := range s.Organizations {
+				fmt.Fprintf(&b, "\n### %s", markdownLink(organization.Organization, organization.Url))
+
+				if organization.OrganizationExtra != "" {
+					fmt.Fprintf(&b, " (%s)", markdownEscape(organization.OrganizationExtra))
+				}
+
+				b.WriteString("\n")
+
+				if organization.Location != "" {
+					fmt.Fprintf(&b, "\n*%s*\n", markdownEscape(organization.Location))
+				}
+
+				s.EachPosition(&organization, func(position *PlanPosition) {
+					markdownPositionTitle(&b, position)
+				}, func(position *PlanPosition) {
+					markdownDetails(&b, position.Summary, position.BulletPoints)
+				})
+			}
+		case SectionEducation:
+			b.WriteString("\n")
+
+			for _, education := range s.Education {
+				fmt.Fprintf(&b, "- **%s**", markdownLink(education.Title, education.Url))
+
+				if education.Institution != "" {
+					fmt.Fprintf(&b, ", *%s*", markdownEscape(education.Institution))
+				}
+
+				b.WriteString("\n")
+			}
+		case SectionProjects:
+			for _, project := range s.Projects {
+				fmt.Fprintf(&b, "\n### %s\n", markdownLink(project.Title, project.Url))
+
+				if project.Location != "" {
+					fmt.Fprintf(&b, "\n*%s*\n", markdownEscape(project.Location))
+				}
+
+				fmt.Fprintf(&b, "\n**%s** | %s\n", markdownEscape(project.Role), markdownDates(project.Dates, " to "))
+
+				markdownDetails(&b, project.Summary, project.BulletPoints)
+			}
+		case SectionCertifications:
+			b.WriteString("\n")
+
+			for _, certification := range s.Certifications {
+				fmt.Fprintf(&b, "- **%s** (%s)", markdownLink(certification.Certification, certification.Url), markdownDates(certification.Dates, "-"))
+
+				if certification.Authority != "" {
+					fmt.Fprintf(&b, ", *%s*", markdownEscape(certification.Authority))
+				}
+
+				b.WriteString("\n")
+			}
+		case SectionCustom:
+			for _, entry := range s.Entries {
+				fmt.Fprintf(&b, "\n**%s**", markdownLink(entry.Title, entry.Url))
+
+				if dates := entryDates(entry.Dates); dates != "" {
+					fmt.Fprintf(&b, " (%s)", markdownEscape(dates))
+				}
+
+				for _, detail := range []string{entry.Subtitle, entry.Location} {
+					if detail != "" {
+						fmt.Fprintf(&b, ", *%s*", markdownEscape(detail))
+					}
+				}
+
+				b.WriteString("\n")
+
+				markdownDetails(&b, "", entry.BulletPoints)
+			}
+		}
+	}
+
+	_, err := b.WriteTo(w)
+
+	return err
+}
+
+func (classicMarkdownRenderer) Extension() string {
+	return "md"
+}
+
+func markdownPositionTitle(b *bytes.Buffer, position *PlanPosition) {
+	fmt.Fprintf(b, "\n**%s**", markdownEscape(position.Title))
+
+	if position.Flavor != "" {
+		fmt.Fprintf(b, " - %s", markdownEscape(position.Flavor))
+	}
+
+	fmt.Fprintf(b, " | %s\n", markdownDates(position.Dates, " to "))
+}
+
+func markdownDetails(b *bytes.Buffer, summary string, bulletPoints []string) {
+	if summary != "" {
+		fmt.Fprintf(b, "\n%s\n", markdownEscape(summary))
+	}
+
+	if len(bulletPoints) > 0 {
+		b.WriteString("\n")
+
+		for _, bulletPoint := range bulletPoints {
+			fmt.Fprintf(b, "- %s\n", markdownEscape(bulletPoint))
+		}
+	}
+}
+
+func markdownDates(dates ConfigurationDates, separator string) string {
+	return markdownEscape(dates.Start + separator + dates.End)
+}
+
+// markdownLink is an inline link, with the URL in angle brackets so that it may
+// contain spaces or parentheses
+func markdownLink(text, url string) string {
+	return linkedText(text, url, markdownEscape, func(text, url string) string {
+		return fmt.Sprintf("[%s](<%s>)", text, url)
+	})
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"#", `\#`,
+	"|", `\|`,
+	"~", `\~`,
+)
+
+func markdownEscape(s string) string {
+	return markdownReplacer.Replace(s)
+}
@@ -0,0 +1,339 @@
+package resume
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fields which identify an entry of a list, in order of preference; an explicit
+// id always wins over the natural keys
+var mergeKeys = []string{
+	"id",
+	"organization",
+	"certification",
+	"title",
+	"name",
+}
+
+// resumeMerger merges resume files into a single document, remembering which
+// file every node came from; encrypted files are decrypted with the passphrase
+//
+// A public merger skips the secret files, and every encrypted file, along with
+// everything they include, so none of their values reach the document
+type resumeMerger struct {
+	document   yaml.Node
+	sources    map[*yaml.Node]string
+	loading    map[string]bool
+	passphrase []byte
+	public     bool
+	secrets    map[string]bool
+}
+
+func newResumeMerger(passphrase []byte) *resumeMerger {
+	return &resumeMerger{
+		sources:    make(map[*yaml.Node]string),
+		loading:    make(map[string]bool),
+		passphrase: passphrase,
+	}
+}
+
+// add merges the resume file, after first merging the files it includes; the
+// includes are relative to the file itself
+func (m *resumeMerger) add(resumeFile string) error {
+	path, err := filepath.Abs(resumeFile)
+
+	if err != nil {
+		return fmt.Errorf("error resolving resume file %s: %w", resumeFile, err)
+	}
+
+	if m.loading[path] {
+		return fmt.Errorf("error including resume file %s: it includes itself", resumeFile)
+	}
+
+	if m.public && m.secrets[path] {
+		return nil
+	}
+
+	m.loading[path] = true
+
+	defer delete(m.loading, path)
+
+	resumeFileBody, err := os.ReadFile(resumeFile)
+
+	if err != nil {
+		return fmt.Errorf("error reading resume file: %w", err)
+	}
+
+	if m.public && IsEncryptedResume(resumeFileBody) {
+		return nil
+	}
+
+	// Decrypted only in memory, and never written back
+	if IsEncryptedResume(resumeFileBody) {
+		if resumeFileBody, err = DecryptResume(resumeFileBody, m.passphrase); err != nil {
+			return fmt.Errorf("error decrypting resume file %s: %w", resumeFile, err)
+		}
+	}
+
+	var node yaml.Node
+
+	if err = yaml.Unmarshal(resumeFileBody, &node); err != nil {
+		return fmt.Errorf("error decoding resume YAML %s: %w", resumeFile, err)
+	}
+
+	includes, err := takeIncludes(&node)
+
+	if err != nil {
+		return fmt.Errorf("error decoding includes of resume file %s: %w", resumeFile, err)
+	}
+
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(resumeFile), include)
+		}
+
+		if err = m.add(include); err != nil {
+			return err
+		}
+	}
+
+	// Nothing to merge from an empty file
+	if node.Kind == 0 {
+		return nil
+	}
+
+	m.remember(&node, resumeFile)
+
+	if m.document.Kind == 0 {
+		m.document = node
+	} else {
+		m.merge(&m.document, &node)
+	}
+
+	return nil
+}
+
+// takeIncludes removes the includes list from the document, which isn't part of
+// the resume itself
+func takeIncludes(document *yaml.Node) ([]string, error) {
+	if (len(document.Content) == 0) || (document.Content[0].Kind != yaml.MappingNode) {
+		return nil, nil
+	}
+
+	mapping := document.Content[0]
+
+	for i := 0; (i + 1) < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != "includes" {
+			continue
+		}
+
+		var includes []string
+
+		if err := mapping.Content[i+1].Decode(&includes); err != nil {
+			return nil, err
+		}
+
+		mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+
+		return includes, nil
+	}
+
+	return nil, nil
+}
+
+func (m *resumeMerger) remember(node *yaml.Node, source string) {
+	m.sources[node] = source
+
+	for _, child := range node.Content {
+		m.remember(child, source)
+	}
+}
+
+// merge merges a resume document into another: mappings are merged key by key,
+// list entries are matched by their merge key and merged, and anything else is
+// replaced
+//
+// Unmatched list entries, and list values which aren't already present, are
+// added after the existing ones
+func (m *resumeMerger) merge(dst, src *yaml.Node) {
+	if (dst.Kind != src.Kind) || (dst.Tag != src.Tag) {
+		m.replace(dst, src)
+
+		return
+	}
+
+	switch dst.Kind {
+	case yaml.DocumentNode:
+		if (len(dst.Content) == 0) || (len(src.Content) == 0) {
+			if len(src.Content) > 0 {
+				dst.Content = src.Content
+			}
+
+			return
+		}
+
+		m.merge(dst.Content[0], src.Content[0])
+	case yaml.MappingNode:
+		for i := 0; (i + 1) < len(src.Content); i += 2 {
+			if value := mappingValue(dst, src.Content[i].Value); value != nil {
+				m.merge(value, src.Content[i+1])
+			} else {
+				dst.Content = append(dst.Content, src.Content[i], src.Content[i+1])
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range src.Content {
+			if match := matchingItem(dst, item); match != nil {
+				m.merge(match, item)
+			} else {
+				dst.Content = append(dst.Content, item)
+			}
+		}
+	default:
+		// An unchanged value still comes from the file which first set it
+		if (dst.Kind != yaml.ScalarNode) || (dst.Value != src.Value) {
+			m.replace(dst, src)
+		}
+	}
+}
+
+func (m *resumeMerger) replace(dst, src *yaml.Node) {
+	*dst = *src
+
+	m.sources[dst] = m.sources[src]
+}
+
+// provenance maps the path of every value of the merged document, such as
+// employment[0].positions[1].bullet_points[2], to the file it came from
+func (m *resumeMerger) provenance() map[string]string {
+	provenance := make(map[string]string)
+
+	if len(m.document.Content) > 0 {
+		m.walk(m.document.Content[0], "", provenance)
+	}
+
+	return provenance
+}
+
+// SourcePaths returns the paths of the sources in order, comparing list indexes
+// as numbers so that employment[2] comes before employment[10]
+func (c *Configuration) SourcePaths() []string {
+	paths := make([]string, 0, len(c.Sources))
+
+	for path := range c.Sources {
+		paths = append(paths, path)
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return sourcePathLess(paths[i], paths[j])
+	})
+
+	return paths
+}
+
+func sourcePathLess(a, b string) bool {
+	as, bs := sourcePathSegments(a), sourcePathSegments(b)
+
+	for i := 0; (i < len(as)) && (i < len(bs)); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+
+		if (aErr == nil) && (bErr == nil) {
+			return an < bn
+		}
+
+		return as[i] < bs[i]
+	}
+
+	return len(as) < len(bs)
+}
+
+// sourcePathSegments splits a path into its keys and list indexes
+func sourcePathSegments(path string) []string {
+	return strings.FieldsFunc(path, func(r rune) bool {
+		return (r == '.') || (r == '[') || (r == ']')
+	})
+}
+
+func (m *resumeMerger) walk(node *yaml.Node, path string, provenance map[string]string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; (i + 1) < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+
+			if path != "" {
+				key = path + "." + key
+			}
+
+			m.walk(node.Content[i+1], key, provenance)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			m.walk(item, path+"["+strconv.Itoa(i)+"]", provenance)
+		}
+	default:
+		provenance[path] = m.sources[node]
+	}
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; (i + 1) < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// matchingItem finds the entry of the list which the item is merged into:
+// mappings match on the first merge key the item has, and scalars on their
+// value
+func matchingItem(sequence, item *yaml.Node) *yaml.Node {
+	switch item.Kind {
+	case yaml.ScalarNode:
+		for _, existing := range sequence.Content {
+			if (existing.Kind == yaml.ScalarNode) && (existing.Value == item.Value) {
+				return existing
+			}
+		}
+	case yaml.MappingNode:
+		key, value := mergeKey(item)
+
+		if key == "" {
+			return nil
+		}
+
+		for _, existing := range sequence.Content {
+			if existing.Kind != yaml.MappingNode {
+				continue
+			}
+
+			if existingValue := mappingValue(existing, key); (existingValue != nil) && (existingValue.Value == value) {
+				return existing
+			}
+		}
+	}
+
+	return nil
+}
+
+func mergeKey(mapping *yaml.Node) (string, string) {
+	for _, key := range mergeKeys {
+		if value := mappingValue(mapping, key); (value != nil) && (value.Kind == yaml.ScalarNode) && (value.Value != "") {
+			return key, value.Value
+		}
+	}
+
+	return "", ""
+}
@@ -0,0 +1,301 @@
+package resume
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeResumeFiles writes the files, which may be in subdirectories, into a
+// temporary directory, and returns the directory
+func writeResumeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, body := range files {
+		path := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+const mergeBase = `
+contact:
+  name: Test Person
+  email_address: public@example.com
+employment:
+  - organization: Acme
+    positions:
+      - title: Manager
+        bullet_points:
+          - Ran the team
+        tags:
+          - mainline
+certifications:
+  - certification: Kubernetes Administrator
+    authority: CNCF
+`
+
+func TestLoadResumesMerge(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		want   Configuration
+	}{
+		{
+			name: "scalars are replaced",
+			secret: `
+contact:
+  email_address: private@example.com
+certifications:
+  - certification: Kubernetes Administrator
+    authority: The Linux Foundation
+`,
+			want: Configuration{
+				Contact: ConfigurationContact{Name: "Test Person", EmailAddress: "private@example.com"},
+				Employment: []ConfigurationOrganization{
+					{Organization: "Acme", Positions: []ConfigurationOrganizationPosition{
+						{Title: "Manager", BulletPoints: []string{"Ran the team"}, Tags: []string{"mainline"}},
+					}},
+				},
+				Certifications: []ConfigurationCertification{
+					{Certification: "Kubernetes Administrator", Authority: "The Linux Foundation"},
+				},
+			},
+		},
+		{
+			name: "list entries are matched by their natural keys",
+			secret: `
+employment:
+  - organization: Acme
+    positions:
+      - title: Manager
+        bullet_points:
+          - Ran the team
+          - A private bullet point
+        tags:
+          - private
+  - organization: Unlisted
+`,
+			want: Configuration{
+				Contact: ConfigurationContact{Name: "Test Person", EmailAddress: "public@example.com"},
+				Employment: []ConfigurationOrganization{
+					{Organization: "Acme", Positions: []ConfigurationOrganizationPosition{
+						{Title: "Manager", BulletPoints: []string{"Ran the team", "A private bullet point"}, Tags: []string{"mainline", "private"}},
+					}},
+					{Organization: "Unlisted"},
+				},
+				Certifications: []ConfigurationCertification{
+					{Certification: "Kubernetes Administrator", Authority: "CNCF"},
+				},
+			},
+		},
+		{
+			name: "an id is matched instead of the natural keys",
+			secret: `
+employment:
+  - id: acme
+    organization: Acme, Inc.
+`,
+			want: Configuration{
+				Contact: ConfigurationContact{Name: "Test Person", EmailAddress: "public@example.com"},
+				Employment: []ConfigurationOrganization{
+					{Organization: "Acme", Positions: []ConfigurationOrganizationPosition{
+						{Title: "Manager", BulletPoints: []string{"Ran the team"}, Tags: []string{"mainline"}},
+					}},
+					{Id: "acme", Organization: "Acme, Inc."},
+				},
+				Certifications: []ConfigurationCertification{
+					{Certification: "Kubernetes Administrator", Authority: "CNCF"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := writeResumeFiles(t, map[string]string{
+				"base.yaml":   mergeBase,
+				"secret.yaml": test.secret,
+			})
+
+			c, err := LoadResume(filepath.Join(dir, "base.yaml"), filepath.Join(dir, "secret.yaml"))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.Sources = nil
+
+			if !reflect.DeepEqual(*c, test.want) {
+				t.Errorf("LoadResume() = %+v, want %+v", *c, test.want)
+			}
+		})
+	}
+}
+
+func TestLoadResumesIncludes(t *testing.T) {
+	dir := writeResumeFiles(t, map[string]string{
+		"base.yaml": mergeBase,
+		"main.yaml": `
+includes:
+  - base.yaml
+contact:
+  email_address: private@example.com
+`,
+	})
+
+	c, err := LoadResumes(filepath.Join(dir, "main.yaml"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The including file is merged after the files it includes
+	want := ConfigurationContact{Name: "Test Person", EmailAddress: "private@example.com"}
+
+	if c.Contact != want {
+		t.Errorf("LoadResumes() contact = %+v, want %+v", c.Contact, want)
+	}
+}
+
+func TestLoadResumesIncludeCycle(t *testing.T) {
+	dir := writeResumeFiles(t, map[string]string{
+		"a.yaml": "includes:\n  - b.yaml\n",
+		"b.yaml": "includes:\n  - a.yaml\n",
+	})
+
+	if _, err := LoadResumes(filepath.Join(dir, "a.yaml")); err == nil {
+		t.Error("LoadResumes() of an include cycle didn't fail")
+	}
+}
+
+func TestLoadResumesProvenance(t *testing.T) {
+	dir := writeResumeFiles(t, map[string]string{
+		"base.yaml": mergeBase,
+		"secret.yaml": `
+contact:
+  email_address: private@example.com
+employment:
+  - organization: Acme
+    positions:
+      - title: Manager
+        bullet_points:
+          - A private bullet point
+`,
+	})
+
+	base, secret := filepath.Join(dir, "base.yaml"), filepath.Join(dir, "secret.yaml")
+
+	c, err := LoadResumes(base, secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]string{
+		"contact.name":                                base,
+		"contact.email_address":                       secret,
+		"employment[0].organization":                  base,
+		"employment[0].positions[0].bullet_points[0]": base,
+		"employment[0].positions[0].bullet_points[1]": secret,
+		"certifications[0].authority":                 base,
+	} {
+		if got := c.Sources[path]; got != want {
+			t.Errorf("Sources[%s] = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestLoadResumeWithoutSecret(t *testing.T) {
+	dir := writeResumeFiles(t, map[string]string{
+		"base.yaml": mergeBase,
+	})
+
+	c, err := LoadResume(filepath.Join(dir, "base.yaml"), filepath.Join(dir, "secret.yaml"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Contact.EmailAddress != "public@example.com" {
+		t.Errorf("LoadResume() email address = %q, want the base one", c.Contact.EmailAddress)
+	}
+}
+
+func TestLoadPublicResumes(t *testing.T) {
+	dir := writeResumeFiles(t, map[string]string{
+		"base.yaml": mergeBase,
+		"secret.yaml": `
+contact:
+  email_address: private@example.com
+employment:
+  - organization: Unlisted
+`,
+		"main.yaml": `
+includes:
+  - secret.yaml
+employment:
+  - organization: Acme
+    location: Remote
+`,
+	})
+
+	secret := filepath.Join(dir, "secret.yaml")
+
+	c, err := LoadPublicResumes(nil, []string{secret}, filepath.Join(dir, "base.yaml"), secret, filepath.Join(dir, "main.yaml"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, source := range c.Sources {
+		if source == secret {
+			t.Errorf("Sources[%s] is the secret resume file", path)
+		}
+	}
+
+	if c.Contact.EmailAddress != "public@example.com" {
+		t.Errorf("LoadPublicResumes() email address = %q, want the base one", c.Contact.EmailAddress)
+	}
+
+	if (len(c.Employment) != 1) || (c.Employment[0].Location != "Remote") {
+		t.Errorf("LoadPublicResumes() employment = %+v, want only Acme, located by main.yaml", c.Employment)
+	}
+}
+
+func TestSourcePaths(t *testing.T) {
+	c := Configuration{Sources: map[string]string{
+		"employment[10].organization":                  "base.yaml",
+		"employment[2].positions[0].bullet_points[10]": "base.yaml",
+		"employment[2].positions[0].bullet_points[9]":  "base.yaml",
+		"employment[2].organization":                   "base.yaml",
+		"contact.name":                                 "base.yaml",
+		"certifications[1].authority":                  "base.yaml",
+		"certifications[1]":                            "base.yaml",
+	}}
+
+	want := []string{
+		"certifications[1]",
+		"certifications[1].authority",
+		"contact.name",
+		"employment[2].organization",
+		"employment[2].positions[0].bullet_points[9]",
+		"employment[2].positions[0].bullet_points[10]",
+		"employment[10].organization",
+	}
+
+	if got := c.SourcePaths(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SourcePaths() = %q, want %q", got, want)
+	}
+}
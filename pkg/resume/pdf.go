@@ -0,0 +1,1028 @@
+package resume
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	FontStyleNormal     string = ""
+	FontStyleBold       string = "B"
+	FontStyleItalic     string = "I"
+	FontStyleBoldItalic string = "BI"
+)
+
+func init() {
+	RegisterRenderer("pdf", DefaultRenderer, classicPdfRenderer{})
+}
+
+type classicPdfRenderer struct{}
+
+func (classicPdfRenderer) Render(p *Plan, w io.Writer, o Options) error {
+	return newPdfDocument(p, o).render(w)
+}
+
+func (classicPdfRenderer) Extension() string {
+	return "pdf"
+}
+
+// pdfDocument carries the state of a single PDF render, so that multiple
+// documents never share layout values
+type pdfDocument struct {
+	pdf              *gofpdf.Fpdf
+	scratch          *gofpdf.Fpdf
+	p                *Plan
+	workingPageWidth float64
+	defaultFont      string
+	fontCoverage     map[string]func(r rune) bool
+	defaultText      *pdfTextEncoder
+	warn             func(message string)
+
+	// Pagination: where content starts below the header, whether nothing has
+	// been drawn on the page yet, and the lowest point drawn while measuring
+	pageTop   float64
+	atPageTop bool
+	bottom    float64
+}
+
+func newPdfDocument(p *Plan, o Options) *pdfDocument {
+	titleSubject := p.Contact.Name + "'s Resume"
+
+	timeDate := o.timestamp()
+
+	pageWidth, pageHeight := p.Controls.Pdf.Page.dimensions()
+
+	pageSetup := &gofpdf.InitType{
+		OrientationStr: gofpdf.OrientationPortrait,
+		UnitStr:        gofpdf.UnitMillimeter,
+		Size:           gofpdf.SizeType{Wd: pageWidth, Ht: pageHeight},
+	}
+
+	pdf := gofpdf.NewCustom(pageSetup)
+
+	// Blocks are drawn on a scratch document of the same setup to measure them
+	scratch := gofpdf.NewCustom(pageSetup)
+
+	d := &pdfDocument{
+		pdf:         pdf,
+		scratch:     scratch,
+		p:           p,
+		defaultFont: p.Controls.Pdf.Fonts.Default,
+		warn:        o.warn,
+	}
+
+	pdf.SetTitle(titleSubject, true)
+	pdf.SetSubject(titleSubject, true)
+	pdf.SetAuthor(p.Contact.Name, true)
+	pdf.SetCreator(p.Contact.Name, true)
+	pdf.SetKeywords(strings.Join(p.Controls.Pdf.Keywords, " "), true)
+	pdf.SetDisplayMode("fullwidth", "SinglePage")
+	// pdf.SetProtection(gofpdf.CnProtectPrint, "", "")
+	pdf.SetCreationDate(timeDate)
+	pdf.SetModificationDate(timeDate)
+
+	d.addFontFamilies()
+
+	d.defaultText = d.newTextEncoder(d.defaultFont)
+	headerText := d.newTextEncoder(p.Controls.Pdf.Fonts.Header).encode
+	footerText := d.newTextEncoder(p.Controls.Pdf.Fonts.Footer).encode
+
+	pdf.SetMargins(p.Controls.Pdf.Margins.Left, p.Controls.Pdf.Margins.Top, p.Controls.Pdf.Margins.Right)
+	pdf.SetAutoPageBreak(true, PageMarginBottom)
+
+	scratch.SetMargins(p.Controls.Pdf.Margins.Left, p.Controls.Pdf.Margins.Top, p.Controls.Pdf.Margins.Right)
+	scratch.SetAutoPageBreak(false, 0)
+
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFont(p.Controls.Pdf.Fonts.Header, FontStyleBoldItalic, 18)
+
+		pdf.Cell(0, 0, headerText(p.Contact.Name))
+
+		pdf.SetFontSize(14)
+
+		pdf.CellFormat(0, 0, headerText(p.Controls.Flavor.Header), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, "")
+
+		pdf.Ln(5)
+
+		d.pageTop = pdf.GetY()
+		d.atPageTop = true
+	})
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetFont(p.Controls.Pdf.Fonts.Footer, FontStyleItalic, 8)
+
+		pdf.SetY(-15)
+
+		footer := fmt.Sprintf("%s_%s_p%d",
+			p.Controls.Flavor.Footer,
+			timeDate.Format("2006-01-02-15-04-05-0700"),
+			pdf.PageNo())
+
+		hasher := sha1.New()
+
+		if _, err := io.WriteString(hasher, footer); err != nil {
+			pdf.SetErrorf("error writing footer into hasher: %s", err)
+
+			return
+		}
+
+		hash := base64.URLEncoding.EncodeToString(hasher.Sum(nil))
+
+		hashWidth := pdf.GetStringWidth(hash)
+		repository := footerText(p.Contact.Repository)
+		footer = footerText(footer)
+
+		repositoryWidth := pdf.GetStringWidth(repository)
+		footerWidth := pdf.GetStringWidth(footer)
+
+		pad := ((d.workingPageWidth - hashWidth - repositoryWidth - footerWidth) / 2)
+
+		pdf.Cell(hashWidth, 8, hash)
+		pdf.CellFormat((repositoryWidth + pad), 8, repository, gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, p.Contact.Repository)
+		pdf.CellFormat((footerWidth + pad), 8, footer, gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, "")
+	})
+
+	width, _ := pdf.GetPageSize()
+	leftMargin, _, rightMargin, _ := pdf.GetMargins()
+
+	d.workingPageWidth = (width - leftMargin - rightMargin)
+
+	return d
+}
+
+func (d *pdfDocument) render(w io.Writer) error {
+	d.pdf.AddPage()
+
+	d.pdfContactLine()
+
+	d.atPageTop = false
+
+	for i := range d.p.Sections {
+		s := &d.p.Sections[i]
+
+		// The title is kept with the first piece of its section
+		pieces := []pdfPiece{d.pdfSectionTitle(s.Title, s.PageBreakBefore)}
+
+		switch s.Kind {
+		case SectionSkills:
+			pieces = append(pieces, d.pdfSkillsSection(s)...)
+		case SectionOrganizations:
+			pieces = append(pieces, d.pdfOrganizationalExperience(s)...)
+		case SectionEducation:
+			pieces = append(pieces, d.pdfEducation(s)...)
+		case SectionProjects:
+			pieces = append(pieces, d.pdfProjects(s)...)
+		case SectionCertifications:
+			pieces = append(pieces, d.pdfCertifications(s)...)
+		case SectionCustom:
+			pieces = append(pieces, d.pdfEntries(s)...)
+		}
+
+		d.paginate(pieces)
+	}
+
+	return d.pdf.Output(w)
+}
+
+// addFontFamilies embeds the TrueType families of the controls, so that they
+// can be used anywhere a core font name can
+func (d *pdfDocument) addFontFamilies() {
+	d.fontCoverage = make(map[string]func(r rune) bool)
+
+	for family, files := range d.p.Controls.Pdf.Fonts.Families {
+		for _, variant := range []struct {
+			style string
+			file  string
+		}{
+			{FontStyleNormal, files.Regular},
+			{FontStyleBold, files.Bold},
+			{FontStyleItalic, files.Italic},
+			{FontStyleBoldItalic, files.BoldItalic},
+		} {
+			if variant.file == "" {
+				variant.file = files.Regular
+			}
+
+			// gofpdf would resolve the file against its own font directory
+			font, err := os.ReadFile(variant.file)
+
+			if err != nil {
+				d.pdf.SetErrorf("error reading font file for family %s: %s", family, err)
+
+				return
+			}
+
+			d.pdf.AddUTF8FontFromBytes(family, variant.style, font)
+			d.scratch.AddUTF8FontFromBytes(family, variant.style, font)
+
+			// The variants of a family are assumed to cover the same characters
+			if variant.style == FontStyleNormal {
+				d.fontCoverage[family] = d.fontFamilyCoverage(family, font)
+			}
+		}
+	}
+}
+
+// fontFamilyCoverage is whether the family's font has a glyph for a character;
+// when its character map can't be read, every character gofpdf embeds is
+// assumed to be covered
+func (d *pdfDocument) fontFamilyCoverage(family string, font []byte) func(r rune) bool {
+	covers, err := ttfCoverage(font)
+
+	if err != nil {
+		d.warn(fmt.Sprintf("error reading characters of font family %s, so none are checked: %s", family, err))
+
+		return func(r rune) bool {
+			return r <= 0xffff
+		}
+	}
+
+	return covers
+}
+
+// text prepares text for drawing in the default font
+func (d *pdfDocument) text(s string) string {
+	return d.defaultText.encode(s)
+}
+
+func (d *pdfDocument) pdfContactLine() {
+	pdf, p := d.pdf, d.p
+
+	fontSize := float64(9)
+
+	pdf.SetFont(d.defaultFont, FontStyleNormal, fontSize)
+
+	type contactEntry struct {
+		text string
+		url  string
+	}
+
+	// A public document shows the placeholder once, in place of both the email
+	// address and the phone number
+	var contact []contactEntry
+
+	for _, entry := range []contactEntry{
+		{p.Contact.Pronouns, ""},
+		{p.Contact.EmailAddress, "mailto:" + p.Contact.EmailAddress},
+		{p.Withheld, ""},
+		{p.Contact.PhoneNumber, phoneNumberUrl(p.Contact.PhoneNumber)},
+		{p.Contact.Url, p.Contact.Url},
+		{p.Contact.Location, ""},
+	} {
+		if entry.text != "" {
+			contact = append(contact, entry)
+		}
+	}
+
+	if len(contact) == 0 {
+		return
+	}
+
+	lengths := make([]float64, len(contact))
+	pad := d.workingPageWidth
+
+	for i := range contact {
+		lengths[i] = pdf.GetStringWidth(d.text(contact[i].text))
+		pad -= lengths[i]
+	}
+
+	// The entries are spread across the page, with the first at the left margin
+	// and the last at the right one
+	if len(contact) > 1 {
+		pad /= float64(len(contact) - 1)
+	}
+
+	pdf.CellFormat(lengths[0], fontSize, d.text(contact[0].text), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignLeft, false, 0, contact[0].url)
+
+	for i := 1; i < len(contact); i++ {
+		pdf.CellFormat((lengths[i] + pad), fontSize, d.text(contact[i].text), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, contact[i].url)
+	}
+}
+
+// pdfPiece is a block of the document which is never split across pages: a
+// line, or a group of lines which read as one, like a wrapped bullet point
+//
+// The lead is the space above the piece, which is dropped at the top of a page;
+// keepWithNext moves the piece to the next page along with the one after it, and
+// pageBreak is whether a page always, automatically, or never starts before it
+type pdfPiece struct {
+	lead         float64
+	draw         func()
+	keepWithNext bool
+	pageBreak    string
+}
+
+// paginate draws the pieces, measuring every chain of pieces kept together
+// first and starting a new page for it when it would run into the footer
+func (d *pdfDocument) paginate(pieces []pdfPiece) {
+	for start := 0; start < len(pieces); {
+		end := (start + 1)
+
+		for (end < len(pieces)) && pieces[end-1].keepWithNext {
+			end++
+		}
+
+		chain := pieces[start:end]
+
+		if (chain[0].pageBreak == PageBreakAlways) && !d.atPageTop {
+			d.pdf.AddPage()
+		}
+
+		height := d.measure(chain)
+
+		switch {
+		case (len(chain) > 1) && (height > (d.pageBottom() - d.pageTop)):
+			// Too tall for any page, so it has to break within itself; the chain
+			// still breaks before itself as its first piece asks
+			for i, piece := range chain {
+				single := pdfPiece{lead: piece.lead, draw: piece.draw}
+
+				if i == 0 {
+					single.pageBreak = piece.pageBreak
+				}
+
+				d.paginate([]pdfPiece{single})
+			}
+
+			start = end
+
+			continue
+		case (chain[0].pageBreak != PageBreakNever) && !d.atPageTop && ((d.pdf.GetY() + height) > d.pageBottom()):
+			d.pdf.AddPage()
+		}
+
+		for _, piece := range chain {
+			d.drawPiece(piece)
+		}
+
+		start = end
+	}
+}
+
+func (d *pdfDocument) drawPiece(piece pdfPiece) {
+	if !d.atPageTop {
+		d.pdf.Ln(piece.lead)
+	}
+
+	piece.draw()
+
+	d.atPageTop = false
+}
+
+// measure draws the pieces on a fresh page of the scratch document, and
+// returns how far down the page they reach
+func (d *pdfDocument) measure(pieces []pdfPiece) float64 {
+	pdf := d.pdf
+	top := d.pageTop
+
+	d.pdf = d.scratch
+	d.bottom = top
+
+	d.scratch.AddPage()
+	d.scratch.SetY(top)
+
+	for _, piece := range pieces {
+		d.scratch.Ln(piece.lead)
+
+		piece.draw()
+	}
+
+	if y := d.scratch.GetY(); y > d.bottom {
+		d.bottom = y
+	}
+
+	d.pdf = pdf
+
+	return (d.bottom - top)
+}
+
+// pageBottom is where gofpdf would break the page on its own
+func (d *pdfDocument) pageBottom() float64 {
+	_, height := d.pdf.GetPageSize()
+	_, margin := d.pdf.GetAutoPageBreak()
+
+	return (height - margin)
+}
+
+// cell and cellFormat draw through to the document, keeping track of the
+// lowest point drawn so that blocks can be measured
+func (d *pdfDocument) cell(w, h float64, txtStr string) {
+	d.cellFormat(w, h, txtStr, gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignLeft, false, 0, "")
+}
+
+func (d *pdfDocument) cellFormat(w, h float64, txtStr, borderStr string, ln int, alignStr string, fill bool, link int, linkStr string) {
+	if bottom := (d.pdf.GetY() + h); bottom > d.bottom {
+		d.bottom = bottom
+	}
+
+	d.pdf.CellFormat(w, h, txtStr, borderStr, ln, alignStr, fill, link, linkStr)
+}
+
+func (d *pdfDocument) pdfSectionTitle(title, pageBreak string) pdfPiece {
+	return pdfPiece{
+		lead:         11,
+		keepWithNext: d.p.Controls.Pdf.KeepTogether.SectionTitles,
+		pageBreak:    pageBreak,
+		draw: func() {
+			pdf := d.pdf
+
+			pdf.SetFont(d.defaultFont, FontStyleBold, 14)
+			pdf.SetFillColor(200, 200, 200)
+			pdf.SetCellMargin(1)
+			pdf.Bookmark(d.text(title), 0, -1)
+			d.cellFormat(0, 8.5, d.text(title), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignLeft, true, 0, "")
+		},
+	}
+}
+
+func (d *pdfDocument) pdfSkillsSection(s *PlanSection) []pdfPiece {
+	return []pdfPiece{{
+		lead: 8,
+		draw: func() {
+			pdf := d.pdf
+
+			fontSize := float64(11)
+			lineBreak := (fontSize / 2)
+
+			pdf.SetFont(d.defaultFont, FontStyleNormal, fontSize)
+
+			skills := make([]string, 0)
+			linesCount := uint(0)
+
+			for _, skill := range s.Skills {
+				skills = append(skills, d.text(skill))
+
+				// Write a line if the *next* skill would be too wide
+				if pdf.GetStringWidth(strings.Join(skills, " / ")) > d.workingPageWidth {
+					if linesCount > 0 {
+						pdf.Ln(lineBreak)
+					}
+
+					d.cellFormat(0, fontSize, strings.Join(skills[0:len(skills)-1], " / "), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignCenter, false, 0, "")
+
+					skills = []string{d.text(skill)}
+
+					linesCount++
+				}
+			}
+
+			// Just in case we have leftovers
+			if len(skills) > 0 {
+				if linesCount > 0 {
+					pdf.Ln(lineBreak)
+				}
+
+				d.cellFormat(0, fontSize, strings.Join(skills, " / "), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignCenter, false, 0, "")
+			}
+		},
+	}}
+}
+
+func (d *pdfDocument) pdfOrganizationalExperience(s *PlanSection) []pdfPiece {
+	var pieces []pdfPiece
+
+	for oi := range s.Organizations {
+		organization := &s.Organizations[oi]
+
+		// Line 1
+		organizationPieces := []pdfPiece{{
+			lead:         8,
+			keepWithNext: d.p.Controls.Pdf.KeepTogether.OrganizationHeaders,
+			draw: func() {
+				d.pdfOrganizationLine(s, organization)
+			},
+		}}
+
+		// The first organization follows the section title
+		if oi > 0 {
+			organizationPieces[0].pageBreak = s.PageBreakBeforeEntry
+		}
+
+		// Line 2+: position titles, then their summaries and bullet points; the
+		// spacing depends on all of the organization's positions, not only those
+		// selected
+		maxPositionIndex := (organization.TotalPositions - 1)
+
+		if s.TitlesOnly() {
+			for pi := range organization.Positions {
+				organizationPieces = append(organizationPieces, d.pdfPositionTitleLine(0, &organization.Positions[pi], (organization.Positions[pi].Index < maxPositionIndex) || (organization.Positions[pi].Summary != "")))
+			}
+
+			for pi := range organization.Positions {
+				organizationPieces = append(organizationPieces, d.pdfDetails(organization.Positions[pi].Summary, organization.Positions[pi].BulletPoints, maxPositionIndex > 0)...)
+			}
+		} else {
+			for pi := range organization.Positions {
+				var lead float64
+
+				if pi > 0 {
+					lead = 8
+				}
+
+				organizationPieces = append(organizationPieces, d.pdfPositionTitleLine(lead, &organization.Positions[pi], !s.Condensed && ((organization.Positions[pi].Index < maxPositionIndex) || (organization.Positions[pi].Summary != ""))))
+				organizationPieces = append(organizationPieces, d.pdfDetails(organization.Positions[pi].Summary, organization.Positions[pi].BulletPoints, maxPositionIndex > 0)...)
+			}
+		}
+
+		pieces = append(pieces, d.pdfTrailingSpace(organizationPieces, 3)...)
+	}
+
+	return pieces
+}
+
+func (d *pdfDocument) pdfOrganizationLine(s *PlanSection, organization *PlanOrganization) {
+	pdf := d.pdf
+
+	fontSize := float64(11)
+	lineBreak := fontSize
+
+	// We need a single break when collapsing to the first position only
+	if organization.TotalPositions == 1 {
+		lineBreak /= 2
+	} else if s.Condensed {
+		lineBreak /= 2
+	} else if s.CollapseMultiplePositions != CollapseMultiplePositionsFull {
+		lineBreak /= 2
+	}
+
+	pdf.SetFont(d.defaultFont, FontStyleItalic, fontSize)
+
+	var organizationExtra string
+
+	if organization.OrganizationExtra != "" {
+		organizationExtra = fmt.Sprintf(" (%s)", organization.OrganizationExtra)
+	}
+
+	organizationWidth := pdf.GetStringWidth(d.text(organization.Organization))
+	organizationExtraWidth := pdf.GetStringWidth(d.text(organizationExtra))
+
+	fontSize = float64(10)
+
+	pdf.SetFontSize(fontSize)
+
+	locationWidth := pdf.GetStringWidth(d.text(organization.Location))
+
+	pad := (d.workingPageWidth - organizationWidth - organizationExtraWidth - locationWidth)
+
+	fontSize = float64(11)
+
+	pdf.SetFontSize(fontSize)
+
+	pdf.Bookmark(d.text(organization.Organization), 1, -1)
+	d.cellFormat(organizationWidth, fontSize, d.text(organization.Organization), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignLeft, false, 0, organization.Url)
+
+	if organizationExtraWidth > 0 {
+		d.cell(organizationExtraWidth, fontSize, d.text(organizationExtra))
+	}
+
+	fontSize = float64(10)
+
+	pdf.SetFontSize(fontSize)
+
+	d.cellFormat((locationWidth + pad), fontSize, d.text(organization.Location), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, "")
+	pdf.Ln(lineBreak)
+}
+
+func (d *pdfDocument) pdfPositionTitleLine(lead float64, position *PlanPosition, renderLineBreak bool) pdfPiece {
+	return pdfPiece{
+		lead:         lead,
+		keepWithNext: d.p.Controls.Pdf.KeepTogether.Positions,
+		draw: func() {
+			pdf := d.pdf
+
+			fontSize := float64(12)
+			lineBreak := (fontSize / 2)
+
+			pdf.SetFont(d.defaultFont, FontStyleBold, fontSize)
+
+			var flavor string
+
+			if position.Flavor != "" {
+				flavor = fmt.Sprintf(" - %s", position.Flavor)
+			}
+
+			dates := fmt.Sprintf("%s to %s", position.Dates.Start, position.Dates.End)
+
+			titleWidth := pdf.GetStringWidth(d.text(position.Title))
+			datesWidth := pdf.GetStringWidth(d.text(dates))
+
+			pdf.SetFontStyle(FontStyleNormal)
+
+			flavorWidth := pdf.GetStringWidth(d.text(flavor))
+
+			pad := (d.workingPageWidth - titleWidth - flavorWidth - datesWidth)
+
+			pdf.SetFontStyle(FontStyleBold)
+			d.cell(titleWidth, fontSize, d.text(position.Title))
+
+			if flavorWidth > 0 {
+				pdf.SetFontStyle(FontStyleNormal)
+				d.cell(flavorWidth, fontSize, d.text(flavor))
+			}
+
+			pdf.SetFontStyle(FontStyleBold)
+			d.cellFormat((datesWidth + pad), fontSize, d.text(dates), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, "")
+
+			if renderLineBreak {
+				pdf.Ln(lineBreak)
+			}
+		},
+	}
+}
+
+// pdfDetails is the summary and bullet points of a position or project
+func (d *pdfDocument) pdfDetails(summary string, bulletPoints []string, multiplePositions bool) []pdfPiece {
+	var pieces []pdfPiece
+
+	// Possible line 3: summary
+	if summary != "" {
+		var lead float64
+
+		if multiplePositions {
+			lead = 8
+		}
+
+		pieces = append(pieces, pdfPiece{
+			lead:         lead,
+			keepWithNext: d.p.Controls.Pdf.KeepTogether.Positions,
+			draw: func() {
+				fontSize := float64(11)
+
+				d.pdf.SetFont(d.defaultFont, FontStyleNormal, fontSize)
+
+				d.cell(0, fontSize, d.text(summary))
+
+				d.pdf.Ln(fontSize)
+			},
+		})
+	}
+
+	// Lines 4+: bullet points
+	for bpi, bulletPoint := range bulletPoints {
+		pieces = append(pieces, d.pdfBulletPoint(bpi > 0, bulletPoint)...)
+	}
+
+	return pieces
+}
+
+// pdfBulletPoint is a piece per line of the bullet point wrapped under the
+// bullet, which are kept together as the controls ask
+func (d *pdfDocument) pdfBulletPoint(spaced bool, bulletPoint string) []pdfPiece {
+	bulletCellWidth := float64(7)
+
+	fontSize := float64(11)
+	lineBreak := (fontSize / 2)
+
+	lines := d.pdfWrap(d.text(bulletPoint), (d.workingPageWidth - bulletCellWidth))
+	pieces := make([]pdfPiece, len(lines))
+
+	// Without a minimum of lines on either page, the bullet point is kept whole
+	minimumLines := int(d.p.Controls.Pdf.KeepTogether.BulletPointLines)
+
+	if minimumLines == 0 {
+		minimumLines = len(lines)
+	}
+
+	for li := range lines {
+		line := lines[li]
+		bullet := ""
+
+		if li == 0 {
+			bullet = string(rune(117))
+		}
+
+		if spaced || (li > 0) {
+			pieces[li].lead = lineBreak
+		}
+
+		pieces[li].keepWithNext = (li < (len(lines) - 1)) && ((li < (minimumLines - 1)) || (li >= (len(lines) - minimumLines)))
+		pieces[li].draw = func() {
+			d.pdf.SetFont("Symbol", FontStyleNormal, float64(6)) // Small bullets
+
+			d.cell(bulletCellWidth, fontSize, bullet)
+
+			d.pdf.SetFont(d.defaultFont, FontStyleNormal, fontSize)
+
+			d.cell(0, fontSize, line)
+		}
+	}
+
+	return pieces
+}
+
+// pdfWrap breaks already encoded text into lines no wider than the width in
+// the default font; a word too wide for any line gets one of its own
+func (d *pdfDocument) pdfWrap(text string, width float64) []string {
+	// Measured on the scratch document, so nothing is drawn
+	d.scratch.SetFont(d.defaultFont, FontStyleNormal, 11)
+
+	lines := make([]string, 0)
+	words := make([]string, 0)
+
+	for _, word := range strings.Split(text, " ") {
+		if (len(words) > 0) && (d.scratch.GetStringWidth(strings.Join(append(words, word), " ")) > width) {
+			lines = append(lines, strings.Join(words, " "))
+			words = []string{}
+		}
+
+		words = append(words, word)
+	}
+
+	return append(lines, strings.Join(words, " "))
+}
+
+// pdfTrailingSpace adds space after the last of the pieces, and lets whatever
+// follows them go to another page
+func (d *pdfDocument) pdfTrailingSpace(pieces []pdfPiece, space float64) []pdfPiece {
+	last := &pieces[len(pieces)-1]
+	draw := last.draw
+
+	last.keepWithNext = false
+	last.draw = func() {
+		draw()
+
+		d.pdf.Ln(space)
+	}
+
+	return pieces
+}
+
+func (d *pdfDocument) pdfEducation(s *PlanSection) []pdfPiece {
+	pieces := make([]pdfPiece, len(s.Education))
+
+	for ei := range s.Education {
+		education := &s.Education[ei]
+
+		fontSize := float64(11)
+		lineBreak := (fontSize / 2)
+
+		pieces[ei].lead = lineBreak
+
+		if ei == 0 {
+			pieces[ei].lead = 8
+		}
+
+		pieces[ei].draw = func() {
+			pdf := d.pdf
+
+			fontSize := float64(11)
+
+			pdf.SetFont(d.defaultFont, FontStyleBold, fontSize)
+
+			titleWidth := pdf.GetStringWidth(d.text(education.Title))
+
+			fontSize = float64(10)
+
+			pdf.SetFont(d.defaultFont, FontStyleItalic, fontSize)
+
+			institutionWidth := pdf.GetStringWidth(d.text(education.Institution))
+			pad := (d.workingPageWidth - titleWidth - institutionWidth)
+
+			pdf.Bookmark(d.text(education.Title), 1, -1)
+
+			fontSize = float64(11)
+
+			pdf.SetFont(d.defaultFont, FontStyleBold, fontSize)
+
+			d.cellFormat((titleWidth + pad), fontSize, d.text(education.Title), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignLeft, false, 0, education.Url)
+
+			fontSize = float64(10)
+
+			pdf.SetFont(d.defaultFont, FontStyleItalic, fontSize)
+
+			fontSize = float64(11) // need the cells to be the same height
+
+			d.cellFormat(institutionWidth, fontSize, d.text(education.Institution), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, "")
+		}
+	}
+
+	return pieces
+}
+
+func (d *pdfDocument) pdfProjects(s *PlanSection) []pdfPiece {
+	var pieces []pdfPiece
+
+	for pi := range s.Projects {
+		project := &s.Projects[pi]
+
+		// Line 1
+		projectPieces := []pdfPiece{{
+			lead:         8,
+			keepWithNext: d.p.Controls.Pdf.KeepTogether.OrganizationHeaders,
+			draw: func() {
+				pdf := d.pdf
+
+				fontSize := float64(11)
+				lineBreak := (fontSize / 2)
+
+				pdf.SetFont(d.defaultFont, FontStyleItalic, fontSize)
+
+				titleWidth := pdf.GetStringWidth(d.text(project.Title))
+
+				fontSize = float64(10)
+
+				pdf.SetFontSize(fontSize)
+
+				locationWidth := pdf.GetStringWidth(d.text(project.Location))
+
+				pad := (d.workingPageWidth - titleWidth - locationWidth)
+
+				fontSize = float64(11)
+
+				pdf.SetFontSize(fontSize)
+
+				pdf.Bookmark(d.text(project.Title), 1, -1)
+				d.cellFormat(titleWidth, fontSize, d.text(project.Title), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignLeft, false, 0, project.Url)
+
+				fontSize = float64(10)
+
+				pdf.SetFontSize(fontSize)
+
+				d.cellFormat((locationWidth + pad), fontSize, d.text(project.Location), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, "")
+				pdf.Ln(lineBreak)
+			},
+		}, {
+			// Line 2: role start
+			keepWithNext: d.p.Controls.Pdf.KeepTogether.Positions,
+			draw: func() {
+				pdf := d.pdf
+
+				fontSize := float64(12)
+				lineBreak := (fontSize / 2)
+
+				pdf.SetFont(d.defaultFont, FontStyleBold, fontSize)
+
+				dates := fmt.Sprintf("%s to %s", project.Dates.Start, project.Dates.End)
+
+				roleWidth := pdf.GetStringWidth(d.text(project.Role))
+				datesWidth := pdf.GetStringWidth(d.text(dates))
+
+				pdf.SetFontStyle(FontStyleNormal)
+
+				pad := (d.workingPageWidth - roleWidth - datesWidth)
+
+				pdf.SetFontStyle(FontStyleBold)
+				d.cell(roleWidth, fontSize, d.text(project.Role))
+
+				pdf.SetFontStyle(FontStyleBold)
+				d.cellFormat((datesWidth + pad), fontSize, d.text(dates), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, "")
+
+				pdf.Ln(lineBreak) // todo determine if necessary
+			},
+		}}
+
+		projectPieces = append(projectPieces, d.pdfDetails(project.Summary, project.BulletPoints, false)...)
+
+		pieces = append(pieces, d.pdfTrailingSpace(projectPieces, 3)...)
+	}
+
+	return pieces
+}
+
+func (d *pdfDocument) pdfCertifications(s *PlanSection) []pdfPiece {
+	pieces := make([]pdfPiece, len(s.Certifications))
+
+	for ci := range s.Certifications {
+		certification := &s.Certifications[ci]
+
+		fontSize := float64(11)
+		lineBreak := (fontSize / 2)
+
+		pieces[ci].lead = lineBreak
+
+		if ci == 0 {
+			pieces[ci].lead = 8
+		}
+
+		pieces[ci].draw = func() {
+			pdf := d.pdf
+
+			fontSize := float64(11)
+
+			pdf.SetFont(d.defaultFont, FontStyleBold, fontSize)
+
+			titleWidth := pdf.GetStringWidth(d.text(certification.Certification))
+
+			pdf.SetFontStyle(FontStyleNormal)
+
+			dates := fmt.Sprintf(" (%s-%s)", certification.Dates.Start, certification.Dates.End)
+			datesWidth := pdf.GetStringWidth(d.text(dates))
+
+			fontSize = float64(10)
+
+			pdf.SetFont(d.defaultFont, FontStyleItalic, fontSize)
+
+			institutionWidth := pdf.GetStringWidth(d.text(certification.Authority))
+			pad := (d.workingPageWidth - titleWidth - datesWidth - institutionWidth)
+
+			pdf.Bookmark(d.text(certification.Certification), 1, -1)
+
+			fontSize = float64(11)
+
+			pdf.SetFont(d.defaultFont, FontStyleBold, fontSize)
+
+			d.cellFormat(titleWidth, fontSize, d.text(certification.Certification), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignLeft, false, 0, certification.Url)
+
+			pdf.SetFontStyle(FontStyleNormal)
+
+			d.cell(datesWidth, fontSize, d.text(dates))
+
+			fontSize = float64(10)
+
+			pdf.SetFont(d.defaultFont, FontStyleItalic, fontSize)
+
+			fontSize = float64(11) // need the cells to be the same height
+
+			d.cellFormat((institutionWidth + pad), fontSize, d.text(certification.Authority), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, "")
+		}
+	}
+
+	return pieces
+}
+
+// pdfEntries draws a custom section like certifications, with the bullet points
+// of each entry below it
+func (d *pdfDocument) pdfEntries(s *PlanSection) []pdfPiece {
+	var pieces []pdfPiece
+
+	for ei := range s.Entries {
+		entry := &s.Entries[ei]
+
+		fontSize := float64(11)
+		lead := (fontSize / 2)
+
+		if ei == 0 {
+			lead = 8
+		}
+
+		pieces = append(pieces, pdfPiece{
+			lead:         lead,
+			keepWithNext: d.p.Controls.Pdf.KeepTogether.Positions && (len(entry.BulletPoints) > 0),
+			draw: func() {
+				pdf := d.pdf
+
+				fontSize := float64(11)
+				lineBreak := (fontSize / 2)
+
+				var dates string
+
+				if entryDates(entry.Dates) != "" {
+					dates = fmt.Sprintf(" (%s)", entryDates(entry.Dates))
+				}
+
+				details := make([]string, 0, 2)
+
+				for _, detail := range []string{entry.Subtitle, entry.Location} {
+					if detail != "" {
+						details = append(details, detail)
+					}
+				}
+
+				detail := strings.Join(details, ", ")
+
+				pdf.SetFont(d.defaultFont, FontStyleBold, fontSize)
+
+				titleWidth := pdf.GetStringWidth(d.text(entry.Title))
+
+				pdf.SetFontStyle(FontStyleNormal)
+
+				datesWidth := pdf.GetStringWidth(d.text(dates))
+
+				pdf.SetFont(d.defaultFont, FontStyleItalic, float64(10))
+
+				detailWidth := pdf.GetStringWidth(d.text(detail))
+				pad := (d.workingPageWidth - titleWidth - datesWidth - detailWidth)
+
+				pdf.Bookmark(d.text(entry.Title), 1, -1)
+
+				pdf.SetFont(d.defaultFont, FontStyleBold, fontSize)
+
+				d.cellFormat(titleWidth, fontSize, d.text(entry.Title), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignLeft, false, 0, entry.Url)
+
+				if datesWidth > 0 {
+					pdf.SetFontStyle(FontStyleNormal)
+
+					d.cell(datesWidth, fontSize, d.text(dates))
+				}
+
+				pdf.SetFont(d.defaultFont, FontStyleItalic, float64(10))
+
+				d.cellFormat((detailWidth + pad), fontSize, d.text(detail), gofpdf.BorderNone, gofpdf.LineBreakNone, gofpdf.AlignRight, false, 0, "")
+
+				if len(entry.BulletPoints) > 0 {
+					pdf.Ln(lineBreak)
+				}
+			},
+		})
+
+		pieces = append(pieces, d.pdfDetails("", entry.BulletPoints, false)...)
+	}
+
+	return pieces
+}
@@ -0,0 +1,186 @@
+package resume
+
+import (
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// testPlacement is where a piece was drawn: its page, and how far below the top
+// of the page's content
+type testPlacement struct {
+	page int
+	y    float64
+}
+
+func testPdfDocument(t *testing.T) *pdfDocument {
+	t.Helper()
+
+	d := newPdfDocument(&Plan{
+		Controls: ConfigurationControls{
+			Pdf: ConfigurationControlsPdf{
+				Fonts: ConfigurationControlsPdfFonts{Header: "Times", Footer: "Times", Default: "Times"},
+			},
+		},
+	}, Options{})
+
+	d.pdf.AddPage()
+
+	return d
+}
+
+// testPiece is a piece of the height, recording where it's drawn, but not where
+// it's measured
+func testPiece(d *pdfDocument, placements *[]testPlacement, lead, height float64, keepWithNext bool) pdfPiece {
+	return pdfPiece{
+		lead:         lead,
+		keepWithNext: keepWithNext,
+		draw: func() {
+			if d.pdf != d.scratch {
+				*placements = append(*placements, testPlacement{d.pdf.PageNo(), math.Round(d.pdf.GetY() - d.pageTop)})
+			}
+
+			d.pdf.SetFont("Times", FontStyleNormal, 10)
+			d.cellFormat(0, height, "", gofpdf.BorderNone, gofpdf.LineBreakNormal, gofpdf.AlignLeft, false, 0, "")
+		},
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// The pieces follow one filling the first page this far, out of the
+		// 254.4mm of letter paper below the header
+		fill   float64
+		pieces func(d *pdfDocument, placements *[]testPlacement) []pdfPiece
+		want   []testPlacement
+	}{
+		{
+			name: "a kept group moves to the next page whole",
+			fill: 229,
+			pieces: func(d *pdfDocument, placements *[]testPlacement) []pdfPiece {
+				return []pdfPiece{
+					testPiece(d, placements, 0, 10, true),
+					testPiece(d, placements, 0, 10, true),
+					testPiece(d, placements, 0, 10, false),
+				}
+			},
+			want: []testPlacement{{2, 0}, {2, 10}, {2, 20}},
+		},
+		{
+			name: "pieces which aren't kept together split",
+			fill: 229,
+			pieces: func(d *pdfDocument, placements *[]testPlacement) []pdfPiece {
+				return []pdfPiece{
+					testPiece(d, placements, 0, 10, false),
+					testPiece(d, placements, 0, 10, false),
+					testPiece(d, placements, 0, 10, false),
+				}
+			},
+			want: []testPlacement{{1, 229}, {1, 239}, {2, 0}},
+		},
+		{
+			name: "the lead of a group counts toward its height",
+			fill: 229,
+			pieces: func(d *pdfDocument, placements *[]testPlacement) []pdfPiece {
+				return []pdfPiece{
+					testPiece(d, placements, 8, 10, true),
+					testPiece(d, placements, 0, 10, false),
+				}
+			},
+			want: []testPlacement{{2, 0}, {2, 10}},
+		},
+		{
+			name: "the lead is dropped at the top of a page",
+			fill: 239,
+			pieces: func(d *pdfDocument, placements *[]testPlacement) []pdfPiece {
+				return []pdfPiece{
+					testPiece(d, placements, 5, 10, false),
+					testPiece(d, placements, 8, 10, false),
+					testPiece(d, placements, 8, 10, false),
+				}
+			},
+			want: []testPlacement{{1, 244}, {2, 0}, {2, 18}},
+		},
+		{
+			name: "a group too tall for any page splits",
+			fill: 229,
+			pieces: func(d *pdfDocument, placements *[]testPlacement) []pdfPiece {
+				var pieces []pdfPiece
+
+				for i := 0; i < 30; i++ {
+					pieces = append(pieces, testPiece(d, placements, 0, 10, i < 29))
+				}
+
+				return pieces
+			},
+			want: func() []testPlacement {
+				var want []testPlacement
+
+				// Each piece still moves to the next page on its own, where 25 of
+				// them fit
+				for i := 0; i < 30; i++ {
+					switch {
+					case i < 2:
+						want = append(want, testPlacement{1, float64(229 + (10 * i))})
+					case i < 27:
+						want = append(want, testPlacement{2, float64(10 * (i - 2))})
+					default:
+						want = append(want, testPlacement{3, float64(10 * (i - 27))})
+					}
+				}
+
+				return want
+			}(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := testPdfDocument(t)
+
+			var placements []testPlacement
+
+			d.paginate([]pdfPiece{testPiece(d, &placements, 0, test.fill, false)})
+
+			placements = nil
+
+			d.paginate(test.pieces(d, &placements))
+
+			if !reflect.DeepEqual(placements, test.want) {
+				t.Errorf("paginate() placements = %v, want %v", placements, test.want)
+			}
+
+			if err := d.pdf.Output(io.Discard); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestMeasureStartsAFreshPage(t *testing.T) {
+	d := testPdfDocument(t)
+
+	var placements []testPlacement
+
+	pieces := []pdfPiece{testPiece(d, &placements, 3, 10, false)}
+
+	first := d.measure(pieces)
+	pages := d.scratch.PageCount()
+
+	if second := d.measure(pieces); second != first {
+		t.Errorf("measure() a second time = %g, want %g", second, first)
+	}
+
+	if d.scratch.PageCount() != (pages + 1) {
+		t.Errorf("measure() drew on page %d of the scratch document, want a fresh page %d", d.scratch.PageCount(), pages+1)
+	}
+
+	if len(placements) > 0 {
+		t.Errorf("measure() drew on the document: %v", placements)
+	}
+}
@@ -0,0 +1,285 @@
+package resume
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// pdfTextEncoder prepares UTF-8 text for drawing in a single font: embedded
+// TrueType fonts take UTF-8 as it is, while the core fonts need cp1252
+//
+// Characters the font can't draw are replaced, and reported once each as a
+// warning, rather than left to become garbage glyphs
+type pdfTextEncoder struct {
+	font   string
+	covers func(r rune) bool
+	utf8   bool
+	warn   func(message string)
+	warned map[rune]bool
+}
+
+// pdfReplacementCharacter stands in for anything a font can't draw
+const pdfReplacementCharacter = '?'
+
+func (d *pdfDocument) newTextEncoder(font string) *pdfTextEncoder {
+	e := &pdfTextEncoder{
+		font:   font,
+		covers: cp1252Covers,
+		warn:   d.warn,
+		warned: make(map[rune]bool),
+	}
+
+	for family, covers := range d.fontCoverage {
+		if strings.EqualFold(family, font) {
+			e.covers = covers
+			e.utf8 = true
+		}
+	}
+
+	return e
+}
+
+func (e *pdfTextEncoder) encode(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if !e.covers(r) {
+			if !e.warned[r] {
+				e.warned[r] = true
+
+				e.warn(fmt.Sprintf("font %s can't render %q (%U), so it's replaced with %q", e.font, r, r, pdfReplacementCharacter))
+			}
+
+			r = pdfReplacementCharacter
+		}
+
+		if e.utf8 {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte(cp1252Byte(r))
+		}
+	}
+
+	return b.String()
+}
+
+// The cp1252 characters which differ from Latin-1, all within 0x80 to 0x9f
+var cp1252Specials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+func cp1252Covers(r rune) bool {
+	if (r < 0x80) || ((r >= 0xa0) && (r <= 0xff)) {
+		return true
+	}
+
+	_, exists := cp1252Specials[r]
+
+	return exists
+}
+
+// cp1252Byte is only ever called with covered characters
+func cp1252Byte(r rune) byte {
+	if b, exists := cp1252Specials[r]; exists {
+		return b
+	}
+
+	return byte(r)
+}
+
+// ttfCoverage reads the character map of a TrueType font, and returns whether
+// the font has a glyph for a character
+//
+// gofpdf only embeds characters of the basic multilingual plane, so any beyond
+// it are never covered
+func ttfCoverage(font []byte) (func(r rune) bool, error) {
+	cmap, err := ttfTable(font, "cmap")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cmap) < 4 {
+		return nil, errors.New("truncated cmap table")
+	}
+
+	// Prefer full Unicode maps over BMP only ones, and Windows over Unicode
+	// platform encodings
+	var best []byte
+	bestRank := 0
+
+	numTables := int(binary.BigEndian.Uint16(cmap[2:]))
+
+	for i := 0; i < numTables; i++ {
+		record := 4 + (i * 8)
+
+		if len(cmap) < (record + 8) {
+			return nil, errors.New("truncated cmap encoding records")
+		}
+
+		platform := binary.BigEndian.Uint16(cmap[record:])
+		encoding := binary.BigEndian.Uint16(cmap[record+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[record+4:]))
+
+		if len(cmap) < (offset + 2) {
+			return nil, errors.New("truncated cmap subtable")
+		}
+
+		format := binary.BigEndian.Uint16(cmap[offset:])
+		rank := 0
+
+		switch {
+		case (format == 12) && (platform == 3) && (encoding == 10):
+			rank = 4
+		case (format == 12) && (platform == 0):
+			rank = 3
+		case (format == 4) && (platform == 3) && (encoding == 1):
+			rank = 2
+		case (format == 4) && (platform == 0):
+			rank = 1
+		}
+
+		if rank > bestRank {
+			best, bestRank = cmap[offset:], rank
+		}
+	}
+
+	switch bestRank {
+	case 0:
+		return nil, errors.New("no Unicode character map")
+	case 1, 2:
+		return ttfFormat4Coverage(best)
+	default:
+		return ttfFormat12Coverage(best)
+	}
+}
+
+func ttfTable(font []byte, tag string) ([]byte, error) {
+	if len(font) < 12 {
+		return nil, errors.New("truncated font")
+	}
+
+	numTables := int(binary.BigEndian.Uint16(font[4:]))
+
+	for i := 0; i < numTables; i++ {
+		record := 12 + (i * 16)
+
+		if len(font) < (record + 16) {
+			return nil, errors.New("truncated table directory")
+		}
+
+		if string(font[record:record+4]) != tag {
+			continue
+		}
+
+		offset := int(binary.BigEndian.Uint32(font[record+8:]))
+		length := int(binary.BigEndian.Uint32(font[record+12:]))
+
+		if len(font) < (offset + length) {
+			return nil, fmt.Errorf("truncated %s table", tag)
+		}
+
+		return font[offset : offset+length], nil
+	}
+
+	return nil, fmt.Errorf("no %s table", tag)
+}
+
+func ttfFormat4Coverage(subtable []byte) (func(r rune) bool, error) {
+	if len(subtable) < 14 {
+		return nil, errors.New("truncated format 4 character map")
+	}
+
+	segCount := int(binary.BigEndian.Uint16(subtable[6:])) / 2
+
+	endCodes := 14
+	startCodes := endCodes + (segCount * 2) + 2
+	idDeltas := startCodes + (segCount * 2)
+	idRangeOffsets := idDeltas + (segCount * 2)
+
+	if len(subtable) < (idRangeOffsets + (segCount * 2)) {
+		return nil, errors.New("truncated format 4 character map")
+	}
+
+	uint16At := func(offset int) (uint16, bool) {
+		if (offset < 0) || (len(subtable) < (offset + 2)) {
+			return 0, false
+		}
+
+		return binary.BigEndian.Uint16(subtable[offset:]), true
+	}
+
+	return func(r rune) bool {
+		if (r < 0) || (r > 0xffff) {
+			return false
+		}
+
+		c := uint16(r)
+
+		for segment := 0; segment < segCount; segment++ {
+			end, _ := uint16At(endCodes + (segment * 2))
+
+			if c > end {
+				continue
+			}
+
+			start, _ := uint16At(startCodes + (segment * 2))
+
+			if c < start {
+				return false
+			}
+
+			idDelta, _ := uint16At(idDeltas + (segment * 2))
+			idRangeOffsetAt := idRangeOffsets + (segment * 2)
+			idRangeOffset, _ := uint16At(idRangeOffsetAt)
+
+			if idRangeOffset == 0 {
+				return (c + idDelta) != 0
+			}
+
+			glyph, ok := uint16At(idRangeOffsetAt + int(idRangeOffset) + (int(c-start) * 2))
+
+			return ok && (glyph != 0)
+		}
+
+		return false
+	}, nil
+}
+
+func ttfFormat12Coverage(subtable []byte) (func(r rune) bool, error) {
+	if len(subtable) < 16 {
+		return nil, errors.New("truncated format 12 character map")
+	}
+
+	numGroups := int(binary.BigEndian.Uint32(subtable[12:]))
+
+	if len(subtable) < (16 + (numGroups * 12)) {
+		return nil, errors.New("truncated format 12 character map")
+	}
+
+	return func(r rune) bool {
+		if (r < 0) || (r > 0xffff) {
+			return false
+		}
+
+		c := uint32(r)
+
+		for group := 0; group < numGroups; group++ {
+			offset := 16 + (group * 12)
+
+			start := binary.BigEndian.Uint32(subtable[offset:])
+			end := binary.BigEndian.Uint32(subtable[offset+4:])
+
+			if (c >= start) && (c <= end) {
+				return (binary.BigEndian.Uint32(subtable[offset+8:]) + (c - start)) != 0
+			}
+		}
+
+		return false
+	}, nil
+}
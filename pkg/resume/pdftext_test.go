@@ -0,0 +1,274 @@
+package resume
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// testTtf builds a font of only the tables given, which is all ttfCoverage reads
+func testTtf(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	var b bytes.Buffer
+
+	testWrite(&b, uint32(0x00010000), uint16(len(tags)), uint16(0), uint16(0), uint16(0))
+
+	offset := 12 + (len(tags) * 16)
+
+	for _, tag := range tags {
+		b.WriteString(tag)
+		testWrite(&b, uint32(0), uint32(offset), uint32(len(tables[tag])))
+
+		offset += len(tables[tag])
+	}
+
+	for _, tag := range tags {
+		b.Write(tables[tag])
+	}
+
+	return b.Bytes()
+}
+
+type testCmapSubtable struct {
+	platform, encoding uint16
+	data               []byte
+}
+
+func testCmap(subtables ...testCmapSubtable) []byte {
+	var b bytes.Buffer
+
+	testWrite(&b, uint16(0), uint16(len(subtables)))
+
+	offset := 4 + (len(subtables) * 8)
+
+	for _, subtable := range subtables {
+		testWrite(&b, subtable.platform, subtable.encoding, uint32(offset))
+
+		offset += len(subtable.data)
+	}
+
+	for _, subtable := range subtables {
+		b.Write(subtable.data)
+	}
+
+	return b.Bytes()
+}
+
+// testCmapFormat4 maps A to Z by delta, and 一 (but not 丁) through the glyph
+// array, followed by the final segment every format 4 map ends with
+func testCmapFormat4() []byte {
+	var b bytes.Buffer
+
+	segCount := 3
+
+	testWrite(&b, uint16(4), uint16(0), uint16(0), uint16(segCount*2), uint16(0), uint16(0), uint16(0))
+	testWrite(&b, uint16('Z'), uint16(0x4e01), uint16(0xffff), uint16(0))
+	testWrite(&b, uint16('A'), uint16(0x4e00), uint16(0xffff))
+	testWrite(&b, uint16(0x10000+1-'A'), uint16(0), uint16(1))
+
+	// The glyph array follows the range offsets, one segment further on each
+	testWrite(&b, uint16(0), uint16(2*(segCount-1)), uint16(0))
+	testWrite(&b, uint16(40), uint16(0))
+
+	return b.Bytes()
+}
+
+// testCmapFormat12 maps a to z, and 😀 which gofpdf can't embed
+func testCmapFormat12() []byte {
+	var b bytes.Buffer
+
+	testWrite(&b, uint16(12), uint16(0), uint32(16+(2*12)), uint32(0), uint32(2))
+	testWrite(&b, uint32('a'), uint32('z'), uint32(1))
+	testWrite(&b, uint32(0x1f600), uint32(0x1f600), uint32(30))
+
+	return b.Bytes()
+}
+
+func testWrite(b *bytes.Buffer, values ...interface{}) {
+	for _, value := range values {
+		// Writing to a buffer never fails
+		_ = binary.Write(b, binary.BigEndian, value)
+	}
+}
+
+func TestTtfCoverage(t *testing.T) {
+	tests := []struct {
+		name      string
+		cmap      []byte
+		covered   []rune
+		uncovered []rune
+	}{
+		{
+			name:      "format 4",
+			cmap:      testCmap(testCmapSubtable{3, 1, testCmapFormat4()}),
+			covered:   []rune{'A', 'Q', 'Z', '一'},
+			uncovered: []rune{'@', '[', 'a', '丁', 0xffff, 0x1f600},
+		},
+		{
+			name:      "format 4 of the Unicode platform",
+			cmap:      testCmap(testCmapSubtable{0, 3, testCmapFormat4()}),
+			covered:   []rune{'A', '一'},
+			uncovered: []rune{'a'},
+		},
+		{
+			name:      "format 12 over format 4",
+			cmap:      testCmap(testCmapSubtable{3, 1, testCmapFormat4()}, testCmapSubtable{3, 10, testCmapFormat12()}),
+			covered:   []rune{'a', 'z'},
+			uncovered: []rune{'A', '一', 0x1f600},
+		},
+		{
+			name:      "Unicode over other platforms",
+			cmap:      testCmap(testCmapSubtable{1, 0, testCmapFormat12()}, testCmapSubtable{3, 1, testCmapFormat4()}),
+			covered:   []rune{'A'},
+			uncovered: []rune{'a'},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			covers, err := ttfCoverage(testTtf(map[string][]byte{"cmap": test.cmap, "head": make([]byte, 54)}))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, r := range test.covered {
+				if !covers(r) {
+					t.Errorf("ttfCoverage() doesn't cover %q", r)
+				}
+			}
+
+			for _, r := range test.uncovered {
+				if covers(r) {
+					t.Errorf("ttfCoverage() covers %q", r)
+				}
+			}
+		})
+	}
+}
+
+func TestTtfCoverageMalformed(t *testing.T) {
+	format4 := testCmapFormat4()
+
+	tests := []struct {
+		name string
+		font []byte
+	}{
+		{"truncated font", []byte{0, 1, 0, 0}},
+		{"truncated table directory", testTtf(map[string][]byte{"cmap": nil})[:20]},
+		{"no cmap table", testTtf(map[string][]byte{"head": make([]byte, 54)})},
+		{"truncated cmap table", testTtf(map[string][]byte{"cmap": {0, 0}})},
+		{"truncated encoding records", testTtf(map[string][]byte{"cmap": testCmap(testCmapSubtable{3, 1, format4})[:8]})},
+		{"subtable out of bounds", testTtf(map[string][]byte{"cmap": testCmap(testCmapSubtable{3, 1, nil})})},
+		{"no Unicode character map", testTtf(map[string][]byte{"cmap": testCmap(testCmapSubtable{1, 0, format4})})},
+		{"truncated format 4", testTtf(map[string][]byte{"cmap": testCmap(testCmapSubtable{3, 1, format4[:20]})})},
+		{"truncated format 12", testTtf(map[string][]byte{"cmap": testCmap(testCmapSubtable{3, 10, testCmapFormat12()[:30]})})},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ttfCoverage(test.font); err == nil {
+				t.Error("ttfCoverage() of a malformed font didn't fail")
+			}
+		})
+	}
+}
+
+func TestFontFamilyCoverageFallback(t *testing.T) {
+	var warnings []string
+
+	d := &pdfDocument{
+		warn: func(message string) { warnings = append(warnings, message) },
+	}
+
+	covers := d.fontFamilyCoverage("Broken", testTtf(map[string][]byte{"head": make([]byte, 54)}))
+
+	if !covers('A') || !covers('一') || covers(0x1f600) {
+		t.Error("fontFamilyCoverage() of a font without a character map doesn't cover the basic multilingual plane")
+	}
+
+	if len(warnings) != 1 {
+		t.Errorf("fontFamilyCoverage() of a font without a character map warnings = %q, want one", warnings)
+	}
+}
+
+func TestPdfTextEncoder(t *testing.T) {
+	covers, err := ttfCoverage(testTtf(map[string][]byte{"cmap": testCmap(testCmapSubtable{3, 1, testCmapFormat4()})}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		font         string
+		s            string
+		want         string
+		wantWarnings []string
+	}{
+		{
+			name: "cp1252",
+			font: "Times",
+			s:    "Café – “quoted” €5 • Œuvre™",
+			want: "Caf\xe9 \x96 \x93quoted\x94 \x805 \x95 \x8cuvre\x99",
+		},
+		{
+			name: "missing from cp1252",
+			font: "Times",
+			s:    "Ludwick 路德维克 Ł",
+			want: "Ludwick ???? ?",
+			wantWarnings: []string{
+				`font Times can't render '路' (U+8DEF), so it's replaced with '?'`,
+				`font Times can't render '德' (U+5FB7), so it's replaced with '?'`,
+				`font Times can't render '维' (U+7EF4), so it's replaced with '?'`,
+				`font Times can't render '克' (U+514B), so it's replaced with '?'`,
+				`font Times can't render 'Ł' (U+0141), so it's replaced with '?'`,
+			},
+		},
+		{
+			name: "embedded font as UTF-8",
+			font: "latin",
+			s:    "ABC一",
+			want: "ABC一",
+		},
+		{
+			name: "missing from an embedded font, warned once",
+			font: "Latin",
+			s:    "Abc丁丁",
+			want: "A????",
+			wantWarnings: []string{
+				`font Latin can't render 'b' (U+0062), so it's replaced with '?'`,
+				`font Latin can't render 'c' (U+0063), so it's replaced with '?'`,
+				`font Latin can't render '丁' (U+4E01), so it's replaced with '?'`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var warnings []string
+
+			d := &pdfDocument{
+				fontCoverage: map[string]func(r rune) bool{"Latin": covers},
+				warn:         func(message string) { warnings = append(warnings, message) },
+			}
+
+			if got := d.newTextEncoder(test.font).encode(test.s); got != test.want {
+				t.Errorf("encode(%q) = %q, want %q", test.s, got, test.want)
+			}
+
+			if !reflect.DeepEqual(warnings, test.wantWarnings) {
+				t.Errorf("encode(%q) warnings = %q, want %q", test.s, warnings, test.wantWarnings)
+			}
+		})
+	}
+}
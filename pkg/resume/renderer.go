@@ -0,0 +1,125 @@
+package resume
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultFormat is used when the options don't select an output format
+	DefaultFormat = "pdf"
+
+	// DefaultRenderer is used when neither the options nor the controls select one
+	DefaultRenderer = "classic"
+)
+
+// Renderer draws a complete resume document, in its own design and format, from
+// a selection plan
+type Renderer interface {
+	Render(p *Plan, w io.Writer, o Options) error
+
+	// Extension is the file extension, without the dot, of rendered documents
+	Extension() string
+}
+
+var (
+	renderersMutex sync.RWMutex
+	renderers      = make(map[string]map[string]Renderer)
+)
+
+// RegisterRenderer makes a renderer available by name for an output format;
+// registering the same name twice for a format panics
+func RegisterRenderer(format, name string, r Renderer) {
+	renderersMutex.Lock()
+	defer renderersMutex.Unlock()
+
+	if r == nil {
+		panic("resume: RegisterRenderer renderer is nil")
+	}
+
+	if _, exists := renderers[format]; !exists {
+		renderers[format] = make(map[string]Renderer)
+	}
+
+	if _, exists := renderers[format][name]; exists {
+		panic("resume: RegisterRenderer called twice for renderer " + format + "/" + name)
+	}
+
+	renderers[format][name] = r
+}
+
+// LookupRenderer returns the renderer registered under the name for a format
+func LookupRenderer(format, name string) (Renderer, error) {
+	renderersMutex.RLock()
+	defer renderersMutex.RUnlock()
+
+	if _, exists := renderers[format]; !exists {
+		return nil, fmt.Errorf("unknown format: %s", format)
+	}
+
+	r, exists := renderers[format][name]
+
+	if !exists {
+		return nil, fmt.Errorf("unknown %s renderer: %s", format, name)
+	}
+
+	return r, nil
+}
+
+// Formats returns the sorted names of all formats with a registered renderer
+func Formats() []string {
+	renderersMutex.RLock()
+	defer renderersMutex.RUnlock()
+
+	formats := make([]string, 0, len(renderers))
+
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+
+	sort.Strings(formats)
+
+	return formats
+}
+
+// Renderers returns the sorted names of all renderers registered for a format
+func Renderers(format string) []string {
+	renderersMutex.RLock()
+	defer renderersMutex.RUnlock()
+
+	names := make([]string, 0, len(renderers[format]))
+
+	for name := range renderers[format] {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// linkedText escapes the text for a format and, when there's a URL to link to,
+// hands both to the format's link; the URL is left for the link to escape
+func linkedText(text, url string, escape func(string) string, link func(text, url string) string) string {
+	if url == "" {
+		return escape(text)
+	}
+
+	return link(escape(text), url)
+}
+
+var phoneNumberReplacer = strings.NewReplacer(
+	"+", "",
+	" ", "",
+	"(", "",
+	")", "",
+	"-", "",
+)
+
+// phoneNumberUrl turns a displayed phone number into a dialable tel: link
+func phoneNumberUrl(phoneNumber string) string {
+	return "tel:" + phoneNumberReplacer.Replace(phoneNumber)
+}
@@ -0,0 +1,683 @@
+package resume
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type SectionKind string
+
+const (
+	SectionSkills         SectionKind = "skills"
+	SectionOrganizations  SectionKind = "organizations"
+	SectionEducation      SectionKind = "education"
+	SectionProjects       SectionKind = "projects"
+	SectionCertifications SectionKind = "certifications"
+	SectionCustom         SectionKind = "custom"
+)
+
+// Plan is the outcome of selecting which parts of a resume appear in a
+// document, and in what order; renderers only ever draw from a plan
+type Plan struct {
+	Contact  ConfigurationContact
+	Controls ConfigurationControls
+	Sections []PlanSection
+
+	// Withheld is shown in place of the email address and phone number of a
+	// public document; empty otherwise
+	Withheld string
+}
+
+// DefaultPublicPlaceholder is shown in place of the withheld contact details
+// when the controls don't set a placeholder
+const DefaultPublicPlaceholder = "Available on request"
+
+// PlanSection is a single titled section of the document; only the entries
+// matching its kind are populated
+type PlanSection struct {
+	Name           string
+	Kind           SectionKind
+	Title          string
+	Skills         []string
+	Organizations  []PlanOrganization
+	Education      []ConfigurationEducation
+	Projects       []ConfigurationProject
+	Certifications []ConfigurationCertification
+	Entries        []ConfigurationEntry
+
+	// PDF page breaks: PageBreakAlways, PageBreakAuto, or PageBreakNever, where
+	// empty is auto
+	PageBreakBefore string
+
+	// Organization sections only
+	Condensed                 bool
+	CollapseMultiplePositions string
+	PageBreakBeforeEntry      string
+}
+
+// TitlesOnly reports whether the section lists every position title of an
+// organization before the details of any of them
+func (s PlanSection) TitlesOnly() bool {
+	return s.CollapseMultiplePositions == CollapseMultiplePositionsTitlesOnly
+}
+
+// EachPosition calls title and details for the organization's positions in the
+// order the section displays them: every title and then every position's
+// details when collapsing to titles only, and otherwise each title followed by
+// its own details
+func (s PlanSection) EachPosition(organization *PlanOrganization, title, details func(position *PlanPosition)) {
+	if s.TitlesOnly() {
+		for pi := range organization.Positions {
+			title(&organization.Positions[pi])
+		}
+
+		for pi := range organization.Positions {
+			details(&organization.Positions[pi])
+		}
+
+		return
+	}
+
+	for pi := range organization.Positions {
+		title(&organization.Positions[pi])
+		details(&organization.Positions[pi])
+	}
+}
+
+type PlanOrganization struct {
+	Organization      string
+	OrganizationExtra string
+	Url               string
+	Location          string
+	Positions         []PlanPosition
+
+	// TotalPositions counts all of the organization's positions, including
+	// those which weren't selected
+	TotalPositions int
+}
+
+// PlanPosition is a position as it should be displayed: the title is already
+// normalized, the dates collapsed, and the bullet points cut to budget
+type PlanPosition struct {
+	// Index is the position's place among all of the organization's positions
+	Index int
+
+	Title        string
+	Flavor       string
+	Summary      string
+	Dates        ConfigurationDates
+	BulletPoints []string
+}
+
+// withholdContact leaves the private contact details out of the plan, for a
+// public document
+func (p *Plan) withholdContact() {
+	p.Contact.EmailAddress = ""
+	p.Contact.PhoneNumber = ""
+
+	p.Withheld = p.Controls.Public.Placeholder
+
+	if p.Withheld == "" {
+		p.Withheld = DefaultPublicPlaceholder
+	}
+}
+
+// Select applies the controls to the resume entries and returns the plan of
+// everything to render
+//
+// The configuration is never modified, so the same one may be selected from
+// any number of times, with any controls
+func Select(c *Configuration) *Plan {
+	p := &Plan{
+		Contact:  c.Contact,
+		Controls: c.Controls,
+	}
+
+	sel := newSelector(c)
+
+	// Sections earlier in the layout have first pick of the entries
+	for _, name := range c.Controls.layoutSections() {
+		p.addSection(sel.selectSection(name))
+	}
+
+	return p
+}
+
+func (sel *selector) selectSection(name string) (PlanSection, bool) {
+	c := sel.c
+	parts := strings.SplitN(name, ".", 2)
+	condensed := (len(parts) == 2) && (parts[1] == "condensed")
+
+	switch parts[0] {
+	case "skills":
+		control := c.Controls.Skills[parts[len(parts)-1]]
+
+		return sel.selectSkills(name, &control)
+	case "employers":
+		return sel.selectOrganizations(name, c.Employment, &c.Controls.Employers, condensed)
+	case "politics":
+		return sel.selectOrganizations(name, c.Politics, &c.Controls.Politics, condensed)
+	case "volunteering":
+		return sel.selectOrganizations(name, c.Volunteering, &c.Controls.Volunteering, condensed)
+	case "education":
+		return sel.selectEducation()
+	case "projects":
+		return sel.selectProjects()
+	case "certifications":
+		return sel.selectCertifications()
+	case "sections":
+		return sel.selectEntries(name, parts[len(parts)-1])
+	}
+
+	return PlanSection{}, false
+}
+
+// selector tracks which entries a single plan has already used up, so that
+// later sections never repeat them
+type selector struct {
+	c              *Configuration
+	skills         map[*ConfigurationSkills]bool
+	organizations  map[*ConfigurationOrganization]bool
+	positions      map[*ConfigurationOrganizationPosition]bool
+	education      map[*ConfigurationEducation]bool
+	projects       map[*ConfigurationProject]bool
+	certifications map[*ConfigurationCertification]bool
+}
+
+func newSelector(c *Configuration) *selector {
+	return &selector{
+		c:              c,
+		skills:         make(map[*ConfigurationSkills]bool),
+		organizations:  make(map[*ConfigurationOrganization]bool),
+		positions:      make(map[*ConfigurationOrganizationPosition]bool),
+		education:      make(map[*ConfigurationEducation]bool),
+		projects:       make(map[*ConfigurationProject]bool),
+		certifications: make(map[*ConfigurationCertification]bool),
+	}
+}
+
+func (p *Plan) addSection(s PlanSection, ok bool) {
+	if ok {
+		p.Sections = append(p.Sections, s)
+	}
+}
+
+// tagsMatch reports whether any of the tags is one we're targeting
+func tagsMatch(tags []string, controlTags []string) bool {
+	for _, tag := range tags {
+		for _, controlTag := range controlTags {
+			if tag == controlTag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (sel *selector) selectSkills(name string, control *ConfigurationControlCountTagged) (PlanSection, bool) {
+	cs := sel.c.Skills
+
+	if (control.Count == 0) || (len(cs) == 0) {
+		return PlanSection{}, false
+	}
+
+	s := PlanSection{
+		Name:            name,
+		Kind:            SectionSkills,
+		Title:           control.Title,
+		PageBreakBefore: control.PageBreakBefore,
+	}
+
+	// Untagged skills are always eligible
+	for csi, css := range cs {
+		if sel.skills[&cs[csi]] || ((len(css.Tags) > 0) && !tagsMatch(css.Tags, control.Tags)) {
+			continue
+		}
+
+		sel.skills[&cs[csi]] = true
+
+		s.Skills = append(s.Skills, css.Name)
+
+		if uint(len(s.Skills)) == control.Count {
+			break
+		}
+	}
+
+	return s, true
+}
+
+func (sel *selector) selectOrganizations(name string, co []ConfigurationOrganization, control *ConfigurationControlsOrganizations, condensed bool) (PlanSection, bool) {
+	if len(co) == 0 {
+		return PlanSection{}, false
+	}
+
+	s := PlanSection{
+		Name:      name,
+		Kind:      SectionOrganizations,
+		Condensed: condensed,
+	}
+
+	var controlCount uint
+	var positionTags []string
+
+	if condensed {
+		s.Title = control.Condensed.Title
+		s.CollapseMultiplePositions = control.Condensed.CollapseMultiplePositions
+		s.PageBreakBefore = control.Condensed.PageBreakBefore
+		s.PageBreakBeforeEntry = control.Condensed.PageBreakBeforeEntry
+		controlCount = control.Condensed.Count
+		positionTags = control.Condensed.PositionTags
+	} else {
+		s.Title = control.Expanded.Title
+		s.CollapseMultiplePositions = control.Expanded.CollapseMultiplePositions
+		s.PageBreakBefore = control.Expanded.PageBreakBefore
+		s.PageBreakBeforeEntry = control.Expanded.PageBreakBeforeEntry
+		controlCount = control.Expanded.Count
+		positionTags = control.Expanded.PositionTags
+	}
+
+	if controlCount == 0 {
+		return PlanSection{}, false
+	}
+
+	maxBulletPointsCount := control.Expanded.BulletPoints.Start
+
+	for coi, organization := range co {
+		if sel.organizations[&co[coi]] || ((len(control.Expanded.Tags) > 0) && !tagsMatch(organization.Tags, control.Expanded.Tags)) {
+			continue
+		}
+
+		sel.organizations[&co[coi]] = true
+
+		po := PlanOrganization{
+			Organization:      organization.Organization,
+			OrganizationExtra: organization.OrganizationExtra,
+			Url:               organization.Url,
+			Location:          organization.Location,
+			TotalPositions:    len(organization.Positions),
+		}
+
+		positions := organization.Positions
+
+		var addPositionTitle = func(i int) {
+			sel.positions[&positions[i]] = true
+
+			pp := PlanPosition{
+				Index:  i,
+				Title:  positions[i].Title,
+				Flavor: positions[i].Flavor,
+				Dates:  positions[i].Dates,
+			}
+
+			if positions[i].NormalizedTitle != "" {
+				pp.Title = positions[i].NormalizedTitle
+			}
+
+			// Collapsing reaches back to the very first position's start date
+			if s.CollapseMultiplePositions == CollapseMultiplePositionsCollapse {
+				pp.Dates.Start = positions[len(positions)-1].Dates.Start
+			}
+
+			po.Positions = append(po.Positions, pp)
+		}
+
+		for epi, position := range positions {
+			if sel.positions[&positions[epi]] || ((len(positionTags) > 0) && !tagsMatch(position.Tags, positionTags)) {
+				continue
+			}
+
+			planIndex := len(po.Positions)
+
+			addPositionTitle(epi)
+
+			// If we're collapsing positions into titles only, then add them
+			if s.TitlesOnly() {
+				for epi2 := range positions {
+					if !sel.positions[&positions[epi2]] {
+						addPositionTitle(epi2)
+					}
+				}
+			}
+
+			if condensed {
+				break
+			}
+
+			po.Positions[planIndex].Summary = position.Summary
+
+			bulletPointsCount := uint(0)
+
+			for _, bulletPoint := range position.BulletPoints {
+				po.Positions[planIndex].BulletPoints = append(po.Positions[planIndex].BulletPoints, bulletPoint)
+
+				// Check if we've hit the limit, and decrement for the next position
+				bulletPointsCount++
+
+				if bulletPointsCount == maxBulletPointsCount {
+					maxBulletPointsCount -= control.Expanded.BulletPoints.Decrement
+
+					break
+				}
+			}
+
+			if (s.CollapseMultiplePositions == CollapseMultiplePositionsCollapse) || (uint(len(po.Positions)) == control.Expanded.PositionsCount) {
+				break
+			}
+		}
+
+		s.Organizations = append(s.Organizations, po)
+
+		if uint(len(s.Organizations)) == controlCount {
+			break
+		}
+	}
+
+	return s, true
+}
+
+func (sel *selector) selectEducation() (PlanSection, bool) {
+	c := sel.c
+	control := &c.Controls.Education
+
+	if (control.Count == 0) || (len(c.Education) == 0) {
+		return PlanSection{}, false
+	}
+
+	s := PlanSection{
+		Name:            "education",
+		Kind:            SectionEducation,
+		Title:           control.Title,
+		PageBreakBefore: control.PageBreakBefore,
+	}
+
+	for ei, education := range c.Education {
+		if sel.education[&c.Education[ei]] || ((len(control.Tags) > 0) && !tagsMatch(education.Tags, control.Tags)) {
+			continue
+		}
+
+		sel.education[&c.Education[ei]] = true
+
+		s.Education = append(s.Education, education)
+
+		if uint(len(s.Education)) == control.Count {
+			break
+		}
+	}
+
+	return s, true
+}
+
+func (sel *selector) selectProjects() (PlanSection, bool) {
+	c := sel.c
+	control := &c.Controls.Projects
+
+	if (control.Count == 0) || (len(c.Projects) == 0) {
+		return PlanSection{}, false
+	}
+
+	s := PlanSection{
+		Name:            "projects",
+		Kind:            SectionProjects,
+		Title:           control.Title,
+		PageBreakBefore: control.PageBreakBefore,
+	}
+
+	for pi, project := range c.Projects {
+		if sel.projects[&c.Projects[pi]] || ((len(control.Tags) > 0) && !tagsMatch(project.Tags, control.Tags)) {
+			continue
+		}
+
+		sel.projects[&c.Projects[pi]] = true
+
+		project.BulletPoints = append([]string(nil), project.BulletPoints...)
+
+		s.Projects = append(s.Projects, project)
+
+		if uint(len(s.Projects)) == control.Count {
+			break
+		}
+	}
+
+	return s, true
+}
+
+func (sel *selector) selectCertifications() (PlanSection, bool) {
+	c := sel.c
+	control := &c.Controls.Certifications
+
+	if (control.Count == 0) || (len(c.Certifications) == 0) {
+		return PlanSection{}, false
+	}
+
+	s := PlanSection{
+		Name:            "certifications",
+		Kind:            SectionCertifications,
+		Title:           control.Title,
+		PageBreakBefore: control.PageBreakBefore,
+	}
+
+	for ci, certification := range c.Certifications {
+		if sel.certifications[&c.Certifications[ci]] || ((len(control.Tags) > 0) && !tagsMatch(certification.Tags, control.Tags)) {
+			continue
+		}
+
+		sel.certifications[&c.Certifications[ci]] = true
+
+		s.Certifications = append(s.Certifications, certification)
+
+		if uint(len(s.Certifications)) == control.Count {
+			break
+		}
+	}
+
+	return s, true
+}
+
+// selectEntries selects from the custom section of the resume with the same
+// name as the controls section
+func (sel *selector) selectEntries(name, section string) (PlanSection, bool) {
+	c := sel.c
+	control := c.Controls.Sections[section]
+
+	if (control.Count == 0) || (len(c.Sections[section]) == 0) {
+		return PlanSection{}, false
+	}
+
+	s := PlanSection{
+		Name:            name,
+		Kind:            SectionCustom,
+		Title:           sectionTitle(control.Title, customSectionTitle(section)),
+		PageBreakBefore: control.PageBreakBefore,
+	}
+
+	for _, entry := range c.Sections[section] {
+		if (len(control.Tags) > 0) && !tagsMatch(entry.Tags, control.Tags) {
+			continue
+		}
+
+		entry.BulletPoints = append([]string(nil), entry.BulletPoints...)
+
+		s.Entries = append(s.Entries, entry)
+
+		if uint(len(s.Entries)) == control.Count {
+			break
+		}
+	}
+
+	return s, true
+}
+
+// SelectAll returns a plan of every entry of the resume, whatever the controls
+// would select, with all positions and bullet points in full; only the section
+// titles of organizations, education, projects, certifications, and custom
+// sections are taken from the controls
+func SelectAll(c *Configuration) *Plan {
+	p := &Plan{
+		Contact:  c.Contact,
+		Controls: c.Controls,
+	}
+
+	if len(c.Skills) > 0 {
+		s := PlanSection{
+			Name:  "skills",
+			Kind:  SectionSkills,
+			Title: "Skills",
+		}
+
+		for _, skill := range c.Skills {
+			s.Skills = append(s.Skills, skill.Name)
+		}
+
+		p.Sections = append(p.Sections, s)
+	}
+
+	p.addSection(allOrganizations("employers", c.Employment, sectionTitle(c.Controls.Employers.Expanded.Title, "Employment")))
+	p.addSection(allOrganizations("politics", c.Politics, sectionTitle(c.Controls.Politics.Expanded.Title, "Politics")))
+	p.addSection(allOrganizations("volunteering", c.Volunteering, sectionTitle(c.Controls.Volunteering.Expanded.Title, "Volunteering")))
+
+	if len(c.Education) > 0 {
+		p.Sections = append(p.Sections, PlanSection{
+			Name:      "education",
+			Kind:      SectionEducation,
+			Title:     sectionTitle(c.Controls.Education.Title, "Education"),
+			Education: append([]ConfigurationEducation(nil), c.Education...),
+		})
+	}
+
+	if len(c.Projects) > 0 {
+		s := PlanSection{
+			Name:  "projects",
+			Kind:  SectionProjects,
+			Title: sectionTitle(c.Controls.Projects.Title, "Projects"),
+		}
+
+		for _, project := range c.Projects {
+			project.BulletPoints = append([]string(nil), project.BulletPoints...)
+
+			s.Projects = append(s.Projects, project)
+		}
+
+		p.Sections = append(p.Sections, s)
+	}
+
+	if len(c.Certifications) > 0 {
+		p.Sections = append(p.Sections, PlanSection{
+			Name:           "certifications",
+			Kind:           SectionCertifications,
+			Title:          sectionTitle(c.Controls.Certifications.Title, "Certifications"),
+			Certifications: append([]ConfigurationCertification(nil), c.Certifications...),
+		})
+	}
+
+	names := make([]string, 0, len(c.Sections))
+
+	for name := range c.Sections {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if len(c.Sections[name]) == 0 {
+			continue
+		}
+
+		s := PlanSection{
+			Name:  "sections." + name,
+			Kind:  SectionCustom,
+			Title: sectionTitle(c.Controls.Sections[name].Title, customSectionTitle(name)),
+		}
+
+		for _, entry := range c.Sections[name] {
+			entry.BulletPoints = append([]string(nil), entry.BulletPoints...)
+
+			s.Entries = append(s.Entries, entry)
+		}
+
+		p.Sections = append(p.Sections, s)
+	}
+
+	return p
+}
+
+// customSectionTitle is the title of a custom section the controls don't title,
+// with the first letter of every word in upper case
+func customSectionTitle(name string) string {
+	words := strings.Fields(strings.ReplaceAll(name, "_", " "))
+
+	for i, word := range words {
+		r, size := utf8.DecodeRuneInString(word)
+
+		words[i] = string(unicode.ToUpper(r)) + word[size:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// entryDates is the dates of a custom section entry, which may be a single date
+func entryDates(dates ConfigurationDates) string {
+	if dates.End == "" {
+		return dates.Start
+	}
+
+	if dates.Start == "" {
+		return dates.End
+	}
+
+	return dates.Start + " to " + dates.End
+}
+
+func sectionTitle(title, fallback string) string {
+	if title == "" {
+		return fallback
+	}
+
+	return title
+}
+
+func allOrganizations(name string, co []ConfigurationOrganization, title string) (PlanSection, bool) {
+	if len(co) == 0 {
+		return PlanSection{}, false
+	}
+
+	s := PlanSection{
+		Name:                      name,
+		Kind:                      SectionOrganizations,
+		Title:                     title,
+		CollapseMultiplePositions: CollapseMultiplePositionsFull,
+	}
+
+	for _, organization := range co {
+		po := PlanOrganization{
+			Organization:      organization.Organization,
+			OrganizationExtra: organization.OrganizationExtra,
+			Url:               organization.Url,
+			Location:          organization.Location,
+			TotalPositions:    len(organization.Positions),
+		}
+
+		for pi, position := range organization.Positions {
+			pp := PlanPosition{
+				Index:        pi,
+				Title:        position.Title,
+				Flavor:       position.Flavor,
+				Summary:      position.Summary,
+				Dates:        position.Dates,
+				BulletPoints: append([]string(nil), position.BulletPoints...),
+			}
+
+			if position.NormalizedTitle != "" {
+				pp.Title = position.NormalizedTitle
+			}
+
+			po.Positions = append(po.Positions, pp)
+		}
+
+		s.Organizations = append(s.Organizations, po)
+	}
+
+	return s, true
+}
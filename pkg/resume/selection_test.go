@@ -0,0 +1,358 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testResume() *Configuration {
+	return &Configuration{
+		Contact: ConfigurationContact{
+			Name: "Test Person",
+		},
+		Skills: []ConfigurationSkills{
+			{Name: "Go", Tags: []string{"technical"}},
+			{Name: "Hiring", Tags: []string{"management"}},
+			{Name: "Kubernetes", Tags: []string{"technical"}},
+			{Name: "Mentoring"},
+		},
+		Employment: []ConfigurationOrganization{
+			{
+				Organization: "Acme",
+				Tags:         []string{"mainline"},
+				Positions: []ConfigurationOrganizationPosition{
+					{
+						Title:        "Manager",
+						Summary:      "Ran the team",
+						Dates:        ConfigurationDates{Start: "Jan. 2021", End: "Present"},
+						BulletPoints: []string{"a1", "a2", "a3", "a4"},
+					},
+					{
+						Title:           "Engineer II",
+						NormalizedTitle: "Engineer",
+						Dates:           ConfigurationDates{Start: "Mar. 2018", End: "Jan. 2021"},
+						BulletPoints:    []string{"b1", "b2", "b3"},
+					},
+					{
+						Title:        "Intern",
+						Dates:        ConfigurationDates{Start: "Jun. 2016", End: "Aug. 2016"},
+						BulletPoints: []string{"c1"},
+					},
+				},
+			},
+			{
+				Organization: "Initech",
+				Tags:         []string{"mainline"},
+				Positions: []ConfigurationOrganizationPosition{
+					{
+						Title:        "Developer",
+						Dates:        ConfigurationDates{Start: "Feb. 2014", End: "Mar. 2018"},
+						BulletPoints: []string{"d1", "d2", "d3", "d4", "d5"},
+					},
+				},
+			},
+			{
+				Organization: "Hobby",
+				Positions: []ConfigurationOrganizationPosition{
+					{
+						Title: "Owner",
+						Dates: ConfigurationDates{Start: "Jan. 2010", End: "Present"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// outline flattens a plan into one line per section, skill, organization, and
+// position, which is easier to compare than the plan itself
+func outline(p *Plan) []string {
+	var lines []string
+
+	for _, s := range p.Sections {
+		lines = append(lines, "section "+s.Name)
+
+		for _, skill := range s.Skills {
+			lines = append(lines, "skill "+skill)
+		}
+
+		for _, organization := range s.Organizations {
+			lines = append(lines, fmt.Sprintf("organization %s of %d", organization.Organization, organization.TotalPositions))
+
+			for _, position := range organization.Positions {
+				lines = append(lines, fmt.Sprintf("position %d %s, %s to %s, %q %v", position.Index, position.Title, position.Dates.Start, position.Dates.End, position.Summary, position.BulletPoints))
+			}
+		}
+	}
+
+	return lines
+}
+
+func expandedEmployers(count uint, collapse string) ConfigurationControlsOrganizations {
+	return ConfigurationControlsOrganizations{
+		Expanded: ConfigurationControlsOrganizationExpanded{
+			Count:                     count,
+			CollapseMultiplePositions: collapse,
+			Tags:                      []string{"mainline"},
+			BulletPoints: ConfigurationControlsEmployersExpandedBulletPoints{
+				Start: 10,
+			},
+		},
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		controls ConfigurationControls
+		want     []string
+	}{
+		{
+			name: "skills by tags and count",
+			controls: ConfigurationControls{
+				Layout: ConfigurationControlsLayout{Sections: []string{"skills.first", "skills.second"}},
+				Skills: ConfigurationControlsSkills{
+					"first":  {Count: 2, Tags: []string{"technical"}},
+					"second": {Count: 5, Tags: []string{"management"}},
+				},
+			},
+			want: []string{
+				"section skills.first",
+				"skill Go",
+				"skill Kubernetes",
+				"section skills.second",
+				"skill Hiring",
+				"skill Mentoring",
+			},
+		},
+		{
+			name: "organizations by tags and count",
+			controls: ConfigurationControls{
+				Layout:    ConfigurationControlsLayout{Sections: []string{"employers.expanded"}},
+				Employers: expandedEmployers(5, CollapseMultiplePositionsFull),
+			},
+			want: []string{
+				"section employers.expanded",
+				"organization Acme of 3",
+				`position 0 Manager, Jan. 2021 to Present, "Ran the team" [a1 a2 a3 a4]`,
+				`position 1 Engineer, Mar. 2018 to Jan. 2021, "" [b1 b2 b3]`,
+				`position 2 Intern, Jun. 2016 to Aug. 2016, "" [c1]`,
+				"organization Initech of 1",
+				`position 0 Developer, Feb. 2014 to Mar. 2018, "" [d1 d2 d3 d4 d5]`,
+			},
+		},
+		{
+			name: "positions count",
+			controls: func() ConfigurationControls {
+				cc := ConfigurationControls{
+					Layout:    ConfigurationControlsLayout{Sections: []string{"employers.expanded"}},
+					Employers: expandedEmployers(1, CollapseMultiplePositionsFull),
+				}
+
+				cc.Employers.Expanded.PositionsCount = 2
+
+				return cc
+			}(),
+			want: []string{
+				"section employers.expanded",
+				"organization Acme of 3",
+				`position 0 Manager, Jan. 2021 to Present, "Ran the team" [a1 a2 a3 a4]`,
+				`position 1 Engineer, Mar. 2018 to Jan. 2021, "" [b1 b2 b3]`,
+			},
+		},
+		{
+			name: "bullet points start and decrement",
+			controls: func() ConfigurationControls {
+				cc := ConfigurationControls{
+					Layout:    ConfigurationControlsLayout{Sections: []string{"employers.expanded"}},
+					Employers: expandedEmployers(1, CollapseMultiplePositionsFull),
+				}
+
+				cc.Employers.Expanded.BulletPoints = ConfigurationControlsEmployersExpandedBulletPoints{
+					Start:     3,
+					Decrement: 1,
+				}
+
+				return cc
+			}(),
+			want: []string{
+				"section employers.expanded",
+				"organization Acme of 3",
+				`position 0 Manager, Jan. 2021 to Present, "Ran the team" [a1 a2 a3]`,
+				`position 1 Engineer, Mar. 2018 to Jan. 2021, "" [b1 b2]`,
+				`position 2 Intern, Jun. 2016 to Aug. 2016, "" [c1]`,
+			},
+		},
+		{
+			name: "collapse to the first position",
+			controls: ConfigurationControls{
+				Layout:    ConfigurationControlsLayout{Sections: []string{"employers.expanded"}},
+				Employers: expandedEmployers(1, CollapseMultiplePositionsCollapse),
+			},
+			want: []string{
+				"section employers.expanded",
+				"organization Acme of 3",
+				`position 0 Manager, Jun. 2016 to Present, "Ran the team" [a1 a2 a3 a4]`,
+			},
+		},
+		{
+			name: "collapse to titles only",
+			controls: ConfigurationControls{
+				Layout:    ConfigurationControlsLayout{Sections: []string{"employers.expanded"}},
+				Employers: expandedEmployers(1, CollapseMultiplePositionsTitlesOnly),
+			},
+			want: []string{
+				"section employers.expanded",
+				"organization Acme of 3",
+				`position 0 Manager, Jan. 2021 to Present, "Ran the team" [a1 a2 a3 a4]`,
+				`position 1 Engineer, Mar. 2018 to Jan. 2021, "" []`,
+				`position 2 Intern, Jun. 2016 to Aug. 2016, "" []`,
+			},
+		},
+		{
+			name: "condensed after expanded",
+			controls: func() ConfigurationControls {
+				cc := ConfigurationControls{
+					Layout:    ConfigurationControlsLayout{Sections: []string{"employers.expanded", "employers.condensed"}},
+					Employers: expandedEmployers(1, CollapseMultiplePositionsFull),
+				}
+
+				cc.Employers.Condensed = ConfigurationControlsOrganizationCondensed{
+					Count:                     5,
+					CollapseMultiplePositions: CollapseMultiplePositionsCollapse,
+				}
+
+				return cc
+			}(),
+			want: []string{
+				"section employers.expanded",
+				"organization Acme of 3",
+				`position 0 Manager, Jan. 2021 to Present, "Ran the team" [a1 a2 a3 a4]`,
+				`position 1 Engineer, Mar. 2018 to Jan. 2021, "" [b1 b2 b3]`,
+				`position 2 Intern, Jun. 2016 to Aug. 2016, "" [c1]`,
+				"section employers.condensed",
+				"organization Initech of 1",
+				`position 0 Developer, Feb. 2014 to Mar. 2018, "" []`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := testResume()
+			c.Controls = test.controls
+
+			if got := outline(Select(c)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Select() =\n%q\nwant\n%q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSelectLeavesResumeUnchanged(t *testing.T) {
+	c := testResume()
+	c.Controls = ConfigurationControls{
+		Layout:    ConfigurationControlsLayout{Sections: []string{"employers.expanded"}},
+		Employers: expandedEmployers(2, CollapseMultiplePositionsTitlesOnly),
+	}
+
+	first := Select(c)
+	second := Select(c)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("second Select() = %+v, want %+v", second, first)
+	}
+
+	want := testResume()
+	want.Controls = c.Controls
+
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("Select() modified the resume: %+v", c)
+	}
+}
+
+func TestGeneratePublic(t *testing.T) {
+	c := testResume()
+	c.Contact.EmailAddress = "private@example.com"
+	c.Contact.PhoneNumber = "+1 555 555 0100"
+	c.Controls.Public.Placeholder = "Ask me"
+
+	var b bytes.Buffer
+
+	if err := Generate(c, &b, Options{Format: "markdown", Public: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(b.String(), "private@example.com") || strings.Contains(b.String(), "555") {
+		t.Errorf("Generate() of a public document shows the contact details:\n%s", b.String())
+	}
+
+	if !strings.Contains(b.String(), "Ask me") {
+		t.Errorf("Generate() of a public document is missing the placeholder:\n%s", b.String())
+	}
+
+	if c.Contact.EmailAddress != "private@example.com" {
+		t.Error("Generate() of a public document modified the resume")
+	}
+}
+
+func TestSelectCopiesBulletPoints(t *testing.T) {
+	c := testResume()
+	c.Sections = map[string][]ConfigurationEntry{
+		"awards": {{Title: "Engineer of the Year", BulletPoints: []string{"e1"}}},
+	}
+	c.Controls = ConfigurationControls{
+		Layout:    ConfigurationControlsLayout{Sections: []string{"employers.expanded", "sections.awards"}},
+		Employers: expandedEmployers(1, CollapseMultiplePositionsFull),
+		Sections:  map[string]ConfigurationControlCountTagged{"awards": {Count: 1}},
+	}
+
+	// Renderers may change the plan as they please without touching the resume
+	for _, p := range []*Plan{Select(c), SelectAll(c)} {
+		for _, s := range p.Sections {
+			for _, organization := range s.Organizations {
+				for _, position := range organization.Positions {
+					for bpi := range position.BulletPoints {
+						position.BulletPoints[bpi] = "changed"
+					}
+				}
+			}
+
+			for _, entry := range s.Entries {
+				for bpi := range entry.BulletPoints {
+					entry.BulletPoints[bpi] = "changed"
+				}
+			}
+		}
+	}
+
+	if got := c.Employment[0].Positions[0].BulletPoints[0]; got != "a1" {
+		t.Errorf("changing the plan changed a position's bullet point to %q", got)
+	}
+
+	if got := c.Sections["awards"][0].BulletPoints[0]; got != "e1" {
+		t.Errorf("changing the plan changed a custom section entry's bullet point to %q", got)
+	}
+}
+
+func TestCustomSectionTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"awards", "Awards"},
+		{"speaking_engagements", "Speaking Engagements"},
+		{"open source", "Open Source"},
+		{"éditions", "Éditions"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := customSectionTitle(test.name); got != test.want {
+			t.Errorf("customSectionTitle(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultTextWidth is the column width plain text wraps at when the controls
+// don't set text.width
+const DefaultTextWidth = 80
+
+func init() {
+	RegisterRenderer("text", DefaultRenderer, classicTextRenderer{})
+}
+
+// classicTextRenderer linearizes the selection into plain UTF-8 text with
+// predictable headings and bullets, so applicant tracking systems can read it
+// without having to reconstruct a layout
+type classicTextRenderer struct{}
+
+func (classicTextRenderer) Render(p *Plan, w io.Writer, o Options) error {
+	t := textDocument{
+		width: p.Controls.Text.Width,
+	}
+
+	if t.width == 0 {
+		t.width = DefaultTextWidth
+	}
+
+	t.paragraph(p.Contact.Name, "", "")
+	t.paragraph(p.Controls.Flavor.Header, "", "")
+
+	for _, contact := range []string{
+		p.Contact.Pronouns,
+		p.Contact.EmailAddress,
+		p.Withheld,
+		p.Contact.PhoneNumber,
+		p.Contact.Url,
+		p.Contact.Location,
+	} {
+		t.paragraph(contact, "", "")
+	}
+
+	for _, s := range p.Sections {
+		t.heading(s.Title)
+
+		switch s.Kind {
+		case SectionSkills:
+			t.paragraph(strings.Join(s.Skills, ", "), "", "")
+		case SectionOrganizations:
+			for oi, organization := range s.Organizations {
+				if oi > 0 {
+					t.blank()
+				}
+
+				name := organization.Organization
+
+				if organization.OrganizationExtra != "" {
+					name += " (" + organization.OrganizationExtra + ")"
+				}
+
+				t.paragraph(name, "", "")
+				t.paragraph(organization.Location, "", "")
+
+				s.EachPosition(&organization, func(position *PlanPosition) {
+					t.paragraph(textPositionTitle(position), "", "")
+				}, func(position *PlanPosition) {
+					t.details(position.Summary, position.BulletPoints)
+				})
+			}
+		case SectionEducation:
+			for _, education := range s.Education {
+				line := education.Title
+
+				if education.Institution != "" {
+					line += ", " + education.Institution
+				}
+
+				t.paragraph(line, "- ", "  ")
+			}
+		case SectionProjects:
+			for pi, project := range s.Projects {
+				if pi > 0 {
+					t.blank()
+				}
+
+				t.paragraph(project.Title, "", "")
+				t.paragraph(project.Url, "", "")
+				t.paragraph(project.Location, "", "")
+				t.paragraph(project.Role+", "+project.Dates.Start+" to "+project.Dates.End, "", "")
+				t.details(project.Summary, project.BulletPoints)
+			}
+		case SectionCertifications:
+			for _, certification := range s.Certifications {
+				line := certification.Certification + " (" + certification.Dates.Start + "-" + certification.Dates.End + ")"
+
+				if certification.Authority != "" {
+					line += ", " + certification.Authority
+				}
+
+				t.paragraph(line, "- ", "  ")
+			}
+		case SectionCustom:
+			for ei, entry := range s.Entries {
+				if ei > 0 {
+					t.blank()
+				}
+
+				line := entry.Title
+
+				if dates := entryDates(entry.Dates); dates != "" {
+					line += " (" + dates + ")"
+				}
+
+				for _, detail := range []string{entry.Subtitle, entry.Location} {
+					if detail != "" {
+						line += ", " + detail
+					}
+				}
+
+				t.paragraph(line, "", "")
+				t.paragraph(entry.Url, "", "")
+				t.details("", entry.BulletPoints)
+			}
+		}
+	}
+
+	_, err := t.b.WriteTo(w)
+
+	return err
+}
+
+func (classicTextRenderer) Extension() string {
+	return "txt"
+}
+
+func textPositionTitle(position *PlanPosition) string {
+	title := position.Title
+
+	if position.Flavor != "" {
+		title += " - " + position.Flavor
+	}
+
+	return title + ", " + position.Dates.Start + " to " + position.Dates.End
+}
+
+type textDocument struct {
+	b     bytes.Buffer
+	width int
+}
+
+func (t *textDocument) blank() {
+	t.b.WriteString("\n")
+}
+
+// heading separates a section from the one before it and underlines its title
+func (t *textDocument) heading(title string) {
+	title = strings.ToUpper(title)
+
+	fmt.Fprintf(&t.b, "\n%s\n%s\n", title, strings.Repeat("=", utf8.RuneCountInString(title)))
+}
+
+func (t *textDocument) details(summary string, bulletPoints []string) {
+	t.paragraph(summary, "", "")
+
+	for _, bulletPoint := range bulletPoints {
+		t.paragraph(bulletPoint, "- ", "  ")
+	}
+}
+
+// paragraph writes the text wrapped to the document width, starting the first
+// line with the lead and indenting the rest; empty text writes nothing
+func (t *textDocument) paragraph(text, lead, indent string) {
+	words := strings.Fields(text)
+
+	if len(words) == 0 {
+		return
+	}
+
+	line := lead + words[0]
+	length := utf8.RuneCountInString(line)
+
+	for _, word := range words[1:] {
+		wordLength := utf8.RuneCountInString(word)
+
+		// Words longer than the width are left on a line of their own
+		if length+1+wordLength > t.width {
+			fmt.Fprintf(&t.b, "%s\n", line)
+
+			line = indent + word
+			length = utf8.RuneCountInString(indent) + wordLength
+
+			continue
+		}
+
+		line += " " + word
+		length += 1 + wordLength
+	}
+
+	fmt.Fprintf(&t.b, "%s\n", line)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/rfpludwick/resume/pkg/resume"
+)
+
+// PassphraseEnvironmentVariable holds the passphrase for encrypted resume files
+// when no key file is given
+const PassphraseEnvironmentVariable = "RESUME_PASSPHRASE"
+
+// readPassphrase reads the passphrase from the key file, or otherwise from the
+// environment; it's empty when neither is set
+func readPassphrase(keyFile string) ([]byte, error) {
+	if keyFile == "" {
+		return []byte(os.Getenv(PassphraseEnvironmentVariable)), nil
+	}
+
+	passphrase, err := os.ReadFile(keyFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Editors like to end files with a newline, which isn't part of the key
+	passphrase = bytes.TrimRight(passphrase, "\r\n")
+
+	if len(passphrase) == 0 {
+		return nil, errors.New("key file is empty")
+	}
+
+	return passphrase, nil
+}
+
+// runEncrypt encrypts a resume file so that it can be committed
+func runEncrypt(arguments []string) {
+	runCrypt("encrypt", arguments, "conf/resume/secret.yaml", "conf/resume/secret.yaml.enc", resume.EncryptResume)
+}
+
+// runDecrypt decrypts a resume file encrypted by runEncrypt
+func runDecrypt(arguments []string) {
+	runCrypt("decrypt", arguments, "conf/resume/secret.yaml.enc", "conf/resume/secret.yaml", resume.DecryptResume)
+}
+
+func runCrypt(name string, arguments []string, defaultInput, defaultOutput string, crypt func(body, passphrase []byte) ([]byte, error)) {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+
+	var (
+		inputFile  string
+		outputFile string
+		keyFile    string
+		overwrite  bool
+	)
+
+	flags.StringVar(&inputFile, "in", defaultInput, "Path to the resume file to "+name)
+	flags.StringVar(&outputFile, "out", defaultOutput, "Path to the resume file to write")
+	flags.StringVar(&keyFile, "key-file", "", "Path to a file holding the passphrase; otherwise it's read from "+PassphraseEnvironmentVariable)
+	flags.BoolVar(&overwrite, "overwrite", false, "Overwrite the output file if it already exists")
+
+	// ExitOnError takes care of any errors
+	_ = flags.Parse(arguments)
+
+	passphrase, err := readPassphrase(keyFile)
+
+	if err != nil {
+		log.Fatal("Error reading passphrase:", err)
+	}
+
+	if len(passphrase) == 0 {
+		log.Fatalf("A passphrase is needed, in a key file or in %s", PassphraseEnvironmentVariable)
+	}
+
+	body, err := os.ReadFile(inputFile)
+
+	if err != nil {
+		log.Fatal("Error reading resume file:", err)
+	}
+
+	if body, err = crypt(body, passphrase); err != nil {
+		log.Fatalf("Error %sing resume file %s: %s", name, inputFile, err)
+	}
+
+	mode := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+
+	if !overwrite {
+		mode |= os.O_EXCL
+	}
+
+	f, err := os.OpenFile(outputFile, mode, 0600)
+
+	if err != nil {
+		log.Fatal("Error writing resume file:", err)
+	}
+
+	if _, err = f.Write(body); err != nil {
+		f.Close()
+
+		log.Fatal("Error writing resume file:", err)
+	}
+
+	if err = f.Close(); err != nil {
+		log.Fatal("Error writing resume file:", err)
+	}
+}